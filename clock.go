@@ -0,0 +1,289 @@
+package golangal
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+// Clock is the interface implemented by RealClock and FakeClock.
+// Code that depends on the current time, timers, or tickers can take a Clock
+// (or an equivalent interface of its own) so tests can control time with a FakeClock.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is the interface version of time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// Ticker is the interface version of time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+	Reset(d time.Duration)
+}
+
+// RealClock returns a Clock that uses the time package.
+func RealClock() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) NewTimer(d time.Duration) Timer         { return realTimer{time.NewTimer(d)} }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+
+type realTimer struct{ *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
+
+type realTicker struct{ *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// FakeClockStart is the time a FakeClock starts at when created by EachFakeClock.
+var FakeClockStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// FakeClock is a Clock where time only moves when Advance or Set is called.
+// Timers and tickers fire (in order) when the clock is advanced past their deadline.
+// Like the time package, timer and ticker channels have a buffer of one,
+// and ticks are dropped if the receiver falls behind.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// NewFakeClock returns a FakeClock set to start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// EachFakeClock returns a FakeClock that is reset to FakeClockStart,
+// with no timers or tickers, before each test.
+//
+// Example:
+//
+//	clock := golangal.EachFakeClock()
+//	It("expires the token", func() {
+//	  token := NewToken(clock, time.Hour)
+//	  clock.Advance(61 * time.Minute)
+//	  Expect(token.Expired()).To(BeTrue())
+//	})
+func EachFakeClock() *FakeClock {
+	c := NewFakeClock(FakeClockStart)
+	ginkgo.BeforeEach(func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.now = FakeClockStart
+		c.waiters = nil
+	})
+	return c
+}
+
+// Now returns the current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since returns the fake time elapsed since t.
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Advance moves the clock forward by d, firing any timers and tickers that are due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set moves the clock to t, firing any timers and tickers that are due.
+// Setting the clock backwards does not fire anything.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for {
+		due := c.nextDue(t)
+		if due == nil {
+			break
+		}
+		if due.when.After(c.now) {
+			c.now = due.when
+		}
+		select {
+		case due.ch <- due.when:
+		default:
+		}
+		if due.period > 0 {
+			// Like time.Ticker, skip the ticks a slow receiver missed,
+			// rather than delivering each one in turn.
+			missed := t.Sub(due.when) / due.period
+			due.when = due.when.Add((missed + 1) * due.period)
+		} else {
+			c.removeWaiter(due)
+		}
+	}
+	c.now = t
+}
+
+// Advancing wraps the function f so that every call first advances the clock by step.
+// f can have any signature, so the result can be polled by Eventually and Consistently,
+// allowing time-dependent code to make progress on each poll.
+//
+//	Eventually(clock.Advancing(time.Second, client.Attempts)).Should(Equal(3))
+func (c *FakeClock) Advancing(step time.Duration, f interface{}) interface{} {
+	fv := reflect.ValueOf(f)
+	if fv.Kind() != reflect.Func {
+		panic("FakeClock.Advancing requires a function")
+	}
+	return reflect.MakeFunc(fv.Type(), func(args []reflect.Value) []reflect.Value {
+		c.Advance(step)
+		if fv.Type().IsVariadic() {
+			return fv.CallSlice(args)
+		}
+		return fv.Call(args)
+	}).Interface()
+}
+
+// After is like time.After.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// Sleep blocks until the clock has been advanced by d.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// NewTimer is like time.NewTimer.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{clock: c, ch: make(chan time.Time, 1)}
+	c.mu.Lock()
+	c.addWaiter(w, d)
+	c.mu.Unlock()
+	return w
+}
+
+// NewTicker is like time.NewTicker. It panics if d is not positive.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for FakeClock.NewTicker")
+	}
+	w := &fakeWaiter{clock: c, ch: make(chan time.Time, 1), period: d}
+	c.mu.Lock()
+	c.addWaiter(w, d)
+	c.mu.Unlock()
+	return fakeTicker{w}
+}
+
+// Waiters returns the number of active timers and tickers.
+// It is useful to wait until code under test has created a timer before advancing the clock:
+//
+//	Eventually(clock.Waiters).Should(Equal(1))
+//	clock.Advance(time.Minute)
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// addWaiter must be called with the lock held.
+func (c *FakeClock) addWaiter(w *fakeWaiter, d time.Duration) {
+	w.when = c.now.Add(d)
+	if d <= 0 && w.period == 0 {
+		select {
+		case w.ch <- c.now:
+		default:
+		}
+		return
+	}
+	c.waiters = append(c.waiters, w)
+}
+
+// removeWaiter must be called with the lock held.
+func (c *FakeClock) removeWaiter(w *fakeWaiter) bool {
+	for i, other := range c.waiters {
+		if other == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// nextDue returns the earliest waiter due at or before t, or nil.
+// Of waiters due at the same time, the one created (or reset) first is returned.
+// It must be called with the lock held.
+func (c *FakeClock) nextDue(t time.Time) *fakeWaiter {
+	var next *fakeWaiter
+	for _, w := range c.waiters {
+		if !w.when.After(t) && (next == nil || w.when.Before(next.when)) {
+			next = w
+		}
+	}
+	return next
+}
+
+type fakeWaiter struct {
+	clock  *FakeClock
+	ch     chan time.Time
+	when   time.Time
+	period time.Duration
+}
+
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.ch
+}
+
+func (w *fakeWaiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.clock.removeWaiter(w)
+}
+
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	active := w.clock.removeWaiter(w)
+	w.clock.addWaiter(w, d)
+	return active
+}
+
+type fakeTicker struct {
+	w *fakeWaiter
+}
+
+func (t fakeTicker) C() <-chan time.Time {
+	return t.w.ch
+}
+
+func (t fakeTicker) Stop() {
+	t.w.Stop()
+}
+
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+	t.w.clock.mu.Lock()
+	defer t.w.clock.mu.Unlock()
+	t.w.clock.removeWaiter(t.w)
+	t.w.period = d
+	t.w.clock.addWaiter(t.w, d)
+}
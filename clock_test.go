@@ -0,0 +1,140 @@
+package golangal_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rgalanakis/golangal"
+)
+
+var _ golangal.Clock = golangal.RealClock()
+var _ golangal.Clock = &golangal.FakeClock{}
+
+var _ = Describe("FakeClock", func() {
+	clock := golangal.EachFakeClock()
+
+	It("starts at FakeClockStart", func() {
+		Expect(clock.Now()).To(Equal(golangal.FakeClockStart))
+	})
+
+	It("advances and sets time", func() {
+		start := clock.Now()
+		clock.Advance(time.Hour)
+		Expect(clock.Since(start)).To(Equal(time.Hour))
+		clock.Set(start)
+		Expect(clock.Now()).To(Equal(start))
+	})
+
+	It("resets before each test (advance)", func() {
+		clock.Advance(time.Hour)
+		clock.NewTimer(time.Hour)
+	})
+
+	It("resets before each test (check)", func() {
+		Expect(clock.Now()).To(Equal(golangal.FakeClockStart))
+		Expect(clock.Waiters()).To(Equal(0))
+	})
+
+	It("fires timers when they are due", func() {
+		timer := clock.NewTimer(time.Minute)
+		after := clock.After(2 * time.Minute)
+		clock.Advance(59 * time.Second)
+		Consistently(timer.C()).ShouldNot(Receive())
+		clock.Advance(time.Second)
+		Expect(timer.C()).To(Receive(Equal(golangal.FakeClockStart.Add(time.Minute))))
+		Expect(after).ToNot(Receive())
+		clock.Advance(time.Minute)
+		Expect(after).To(Receive())
+		Expect(clock.Waiters()).To(Equal(0))
+	})
+
+	It("can stop and reset timers", func() {
+		timer := clock.NewTimer(time.Minute)
+		Expect(timer.Stop()).To(BeTrue())
+		Expect(timer.Stop()).To(BeFalse())
+		clock.Advance(time.Hour)
+		Expect(timer.C()).ToNot(Receive())
+		Expect(timer.Reset(time.Second)).To(BeFalse())
+		clock.Advance(time.Second)
+		Expect(timer.C()).To(Receive())
+	})
+
+	It("fires immediately for non-positive durations", func() {
+		Expect(clock.After(0)).To(Receive())
+	})
+
+	It("fires tickers repeatedly, dropping ticks for slow receivers", func() {
+		ticker := clock.NewTicker(time.Second)
+		clock.Advance(time.Second)
+		Expect(ticker.C()).To(Receive(Equal(golangal.FakeClockStart.Add(time.Second))))
+		clock.Advance(3 * time.Second)
+		Expect(ticker.C()).To(Receive(Equal(golangal.FakeClockStart.Add(2 * time.Second))))
+		Expect(ticker.C()).ToNot(Receive())
+		ticker.Reset(time.Minute)
+		clock.Advance(time.Minute)
+		Expect(ticker.C()).To(Receive(Equal(golangal.FakeClockStart.Add(4*time.Second + time.Minute))))
+		ticker.Stop()
+		clock.Advance(time.Hour)
+		Expect(ticker.C()).ToNot(Receive())
+	})
+
+	It("skips missed ticks in one step when advancing far", func() {
+		tickers := make([]golangal.Ticker, 10)
+		for i := range tickers {
+			tickers[i] = clock.NewTicker(time.Millisecond)
+		}
+		start := time.Now()
+		clock.Advance(time.Hour)
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		for _, ticker := range tickers {
+			Expect(ticker.C()).To(Receive(Equal(golangal.FakeClockStart.Add(time.Millisecond))))
+			Expect(ticker.C()).ToNot(Receive())
+		}
+		clock.Advance(time.Millisecond)
+		for _, ticker := range tickers {
+			Expect(ticker.C()).To(Receive(Equal(golangal.FakeClockStart.Add(time.Hour + time.Millisecond))))
+		}
+	})
+
+	It("fires waiters in deadline order", func() {
+		fired := make(chan int, 2)
+		t1, t2 := clock.NewTimer(2*time.Second), clock.NewTimer(time.Second)
+		go func() {
+			for i := 0; i < 2; i++ {
+				select {
+				case <-t1.C():
+					fired <- 1
+				case <-t2.C():
+					fired <- 2
+				}
+			}
+		}()
+		clock.Advance(time.Second)
+		Eventually(fired).Should(Receive(Equal(2)))
+		clock.Advance(time.Second)
+		Eventually(fired).Should(Receive(Equal(1)))
+	})
+
+	It("unblocks Sleep", func() {
+		done := make(chan struct{})
+		go func() {
+			clock.Sleep(time.Minute)
+			close(done)
+		}()
+		Eventually(clock.Waiters).Should(Equal(1))
+		clock.Advance(time.Minute)
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("can advance as Eventually polls", func() {
+		deadline := clock.Now().Add(5 * time.Second)
+		expired := func() bool { return !clock.Now().Before(deadline) }
+		Eventually(clock.Advancing(time.Second, expired)).Should(BeTrue())
+		Expect(clock.Now()).To(Equal(deadline))
+	})
+
+	It("works with the real clock", func() {
+		Expect(golangal.RealClock().Now()).To(golangal.BeWithinDurationOf(time.Now(), time.Second))
+	})
+})
@@ -0,0 +1,113 @@
+package golangal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/rgalanakis/golangal/matchers"
+)
+
+// FileTree describes a directory layout, for use with EachFileTree, WriteFileTree, and HaveFileTree.
+// Keys are slash-separated paths relative to the root of the tree,
+// and values are file contents (string or []byte) or the result of File, Dir, or Symlink.
+// Parent directories are created as needed.
+//
+//	golangal.FileTree{
+//	  "config.yml": "debug: true",
+//	  "bin/run":    golangal.File("#!/bin/sh", 0755),
+//	  "empty":      golangal.Dir(0700),
+//	  "current":    golangal.Symlink("bin"),
+//	}
+type FileTree = matchers.FileTree
+
+// File is a FileTree entry for a regular file with the given contents and mode.
+// When used with HaveFileTree, contents can also be a gomega matcher.
+func File(contents interface{}, mode os.FileMode) matchers.FileEntry {
+	return matchers.FileEntry{Content: contents, Mode: mode}
+}
+
+// Dir is a FileTree entry for a directory with the given mode.
+// Directories only need to be specified if they are empty or need a specific mode.
+func Dir(mode os.FileMode) matchers.FileEntry {
+	return matchers.FileEntry{Dir: true, Mode: mode}
+}
+
+// Symlink is a FileTree entry for a symbolic link to target.
+func Symlink(target string) matchers.FileEntry {
+	return matchers.FileEntry{Symlink: target}
+}
+
+// EachFileTree is like EachTempDir, but the file tree is written into the
+// temporary directory before each test.
+//
+// Example:
+//
+//	rootdir := golangal.EachFileTree(golangal.FileTree{"app/config.yml": "debug: true"})
+//	It("finds the config", func() {
+//	  Expect(FindConfig(rootdir())).To(Equal(filepath.Join(rootdir(), "app", "config.yml")))
+//	})
+func EachFileTree(tree FileTree) func() string {
+	tempdir := EachTempDir()
+	ginkgo.BeforeEach(func() {
+		WriteFileTree(tempdir(), tree)
+	})
+	return tempdir
+}
+
+// WriteFileTree writes the file tree into the directory root.
+// It fails the current test if anything cannot be written.
+func WriteFileTree(root string, tree FileTree) {
+	paths := make([]string, 0, len(tree))
+	for p := range tree {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		entry := matchers.ToFileEntry(tree[p])
+		path := filepath.Join(root, filepath.FromSlash(p))
+		gomega.Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(gomega.Succeed())
+		switch {
+		case entry.Symlink != "":
+			gomega.Expect(os.Symlink(entry.Symlink, path)).To(gomega.Succeed())
+		case entry.Dir:
+			gomega.Expect(os.MkdirAll(path, 0755)).To(gomega.Succeed())
+		default:
+			var contents []byte
+			switch c := entry.Content.(type) {
+			case nil:
+			case string:
+				contents = []byte(c)
+			case []byte:
+				contents = c
+			default:
+				ginkgo.Fail(fmt.Sprintf("FileTree contents of %s must be a string or []byte, got %T", p, c))
+			}
+			gomega.Expect(ioutil.WriteFile(path, contents, 0644)).To(gomega.Succeed())
+		}
+	}
+	// Apply modes deepest-first, so restrictive directory modes don't prevent writing their children.
+	for i := len(paths) - 1; i >= 0; i-- {
+		entry := matchers.ToFileEntry(tree[paths[i]])
+		if entry.Mode != 0 && entry.Symlink == "" {
+			gomega.Expect(os.Chmod(filepath.Join(root, filepath.FromSlash(paths[i])), entry.Mode)).To(gomega.Succeed())
+		}
+	}
+}
+
+// HaveFileTree succeeds if the actual directory path contains exactly the given file tree.
+// Every path in the tree must exist with the same type, contents, symlink target,
+// and mode (if non-zero), and there must be no extra paths.
+// On failure, every missing, extra, and changed path is printed.
+//
+//	Expect(outputDir).To(HaveFileTree(golangal.FileTree{
+//	  "index.html": ContainSubstring("<html>"),
+//	  "assets/app.js": "console.log('hi')",
+//	}))
+func HaveFileTree(tree FileTree) gomega.OmegaMatcher {
+	return &matchers.HaveFileTreeMatcher{Tree: tree}
+}
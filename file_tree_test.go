@@ -0,0 +1,42 @@
+package golangal_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("EachFileTree and WriteFileTree", func() {
+	rootdir := golangal.EachFileTree(golangal.FileTree{
+		"a.txt":          "hello",
+		"sub/deep/b.bin": []byte{1, 2},
+		"sub/run.sh":     golangal.File("#!/bin/sh", 0750),
+		"empty":          golangal.Dir(0700),
+		"link":           golangal.Symlink("a.txt"),
+	})
+
+	It("writes the tree into a temporary directory", func() {
+		Expect(rootdir()).To(HavePrefix(os.TempDir()))
+		Expect(os.ReadFile(filepath.Join(rootdir(), "a.txt"))).To(BeEquivalentTo("hello"))
+		Expect(os.ReadFile(filepath.Join(rootdir(), "sub", "deep", "b.bin"))).To(Equal([]byte{1, 2}))
+		Expect(os.ReadFile(filepath.Join(rootdir(), "link"))).To(BeEquivalentTo("hello"))
+	})
+
+	It("applies modes", func() {
+		info, err := os.Stat(filepath.Join(rootdir(), "sub", "run.sh"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0750)))
+		info, err = os.Stat(filepath.Join(rootdir(), "empty"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.IsDir()).To(BeTrue())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0700)))
+	})
+
+	It("can write into an existing directory", func() {
+		golangal.WriteFileTree(rootdir(), golangal.FileTree{"sub/c.txt": "c"})
+		Expect(filepath.Join(rootdir(), "sub", "c.txt")).To(BeARegularFile())
+	})
+})
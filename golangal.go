@@ -1,12 +1,15 @@
 package golangal
 
 import (
+	"github.com/hashicorp/go-multierror"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
 	"github.com/rgalanakis/golangal/internal"
 	"github.com/rgalanakis/golangal/matchers"
 	"io/ioutil"
 	"os"
+	"time"
 )
 
 type ginkgoHook func(args ...interface{}) bool
@@ -23,18 +26,75 @@ type ginkgoHook func(args ...interface{}) bool
 //	  ...
 //	})
 func EachTempDir() func() string {
-	return eachTempDir("each", ginkgo.BeforeEach, ginkgo.AfterEach)
+	return eachTempDir("each", ginkgo.BeforeEach)
 }
 
-func eachTempDir(scope string, before, after ginkgoHook) func() string {
+// ContainerTempDir is like EachTempDir, but the temporary directory is shared by
+// every test in an Ordered container. It is created in a BeforeAll,
+// and cleaned up after the last test in the container has finished.
+// Use it for expensive fixtures, like built binaries, that only need to be created once.
+//
+// Example:
+//
+//	Describe("the cli", Ordered, func() {
+//	  bindir := golangal.ContainerTempDir()
+//	  BeforeAll(func() {
+//	    // build into bindir()
+//	  })
+//	  ...
+//	})
+func ContainerTempDir() func() string {
+	return eachTempDir("container", ginkgo.BeforeAll)
+}
+
+// SuiteTempDir is like EachTempDir, but the temporary directory is shared by
+// every test in the suite (per parallel process). It is created in a BeforeSuite,
+// and cleaned up after the suite has finished.
+//
+// Because Ginkgo only allows a single BeforeSuite, SuiteTempDir registers the suite's BeforeSuite.
+// In a suite with its own BeforeSuite (or SynchronizedBeforeSuite),
+// or that needs more than one suite directory, call TempDir from it instead.
+//
+// Example:
+//
+//	var suiteTempDir = golangal.SuiteTempDir()
+func SuiteTempDir() func() string {
+	return eachTempDir("suite", beforeSuite)
+}
+
+func beforeSuite(args ...interface{}) bool {
+	return ginkgo.BeforeSuite(args[0])
+}
+
+// TempDir creates a temporary directory and returns its path.
+// Like SetEnv, it is cleaned up using DeferCleanup when the current node's scope ends:
+// after the test when called from an It or BeforeEach,
+// after the Ordered container when called from a BeforeAll,
+// and after the suite when called from a BeforeSuite.
+// Use it to compose temporary directories with your own setup:
+//
+//	var cacheDir, dataDir string
+//	var _ = BeforeSuite(func() {
+//	  cacheDir = golangal.TempDir()
+//	  dataDir = golangal.TempDir()
+//	  // start services using the directories
+//	})
+func TempDir() string {
+	return tempDir("tmp")
+}
+
+func tempDir(scope string) string {
+	td, err := ioutil.TempDir("", "galangal-"+scope)
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	// DeferCleanup runs after the matching After node, and also when a test is interrupted.
+	ginkgo.DeferCleanup(os.RemoveAll, td)
+	return td
+}
+
+func eachTempDir(scope string, before ginkgoHook) func() string {
 	var tempdir string
 	before(func() {
-		td, err := ioutil.TempDir("", "galangal-"+scope)
-		gomega.Expect(err).ToNot(gomega.HaveOccurred())
-		tempdir = td
-	})
-	after(func() {
-		gomega.Expect(os.RemoveAll(tempdir)).To(gomega.Succeed())
+		tempdir = tempDir(scope)
 	})
 	return func() string {
 		return tempdir
@@ -43,6 +103,7 @@ func eachTempDir(scope string, before, after ginkgoHook) func() string {
 
 // EnvVars returns a function that can be called to set an environment variable for the duration of the test,
 // and unset it (if it did not exist) or restore the original value (if it did exist) after the test.
+// The returned function is SetEnv.
 //
 // Example:
 //
@@ -52,46 +113,331 @@ func eachTempDir(scope string, before, after ginkgoHook) func() string {
 //	  ...
 //	})
 func EnvVars() func(key, value string) {
-	var envVars map[string]*string
-	ginkgo.BeforeEach(func() {
-		envVars = make(map[string]*string, 4)
-	})
-	ginkgo.AfterEach(func() {
-		for k, v := range envVars {
-			if v == nil {
-				gomega.Expect(os.Unsetenv(k)).To(gomega.Succeed())
-			} else {
-				gomega.Expect(os.Setenv(k, *v)).To(gomega.Succeed())
-			}
-		}
-	})
-	return func(k, v string) {
-		orig, exists := os.LookupEnv(k)
-		if exists {
-			envVars[k] = &orig
+	return SetEnv
+}
+
+// SetEnv sets an environment variable, and restores the original environment
+// when the current node's scope ends, even if the test fails or panics:
+// after the test when called from an It or BeforeEach,
+// after the Ordered container when called from a BeforeAll,
+// and after the suite when called from a BeforeSuite.
+//
+//	BeforeAll(func() {
+//	  golangal.SetEnv("DATABASE_URL", "postgres://localhost/test")
+//	})
+func SetEnv(key, value string) {
+	changeEnv(map[string]string{key: value}, nil)
+}
+
+// UnsetEnv unsets an environment variable, and restores it like SetEnv.
+// Use it to test code paths where a variable is missing.
+func UnsetEnv(key string) {
+	changeEnv(nil, []string{key})
+}
+
+// SetEnvs sets every environment variable in vars, and restores them like SetEnv.
+func SetEnvs(vars map[string]string) {
+	changeEnv(vars, nil)
+}
+
+// LoadEnvFile sets every environment variable in the dotenv-formatted file at path,
+// and restores them like SetEnv.
+// Lines are in the form KEY=VALUE, and can be prefixed with 'export '.
+// Values can be single or double quoted. Blank lines and # comments are ignored.
+func LoadEnvFile(path string) {
+	f, err := os.Open(path)
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	defer f.Close()
+	vars, err := internal.ParseDotEnv(f)
+	gomega.Expect(err).ToNot(gomega.HaveOccurred(), "parsing %s", path)
+	changeEnv(vars, nil)
+}
+
+func changeEnv(set map[string]string, unset []string) {
+	original := make(map[string]*string, len(set)+len(unset))
+	record := func(k string) {
+		if v, exists := os.LookupEnv(k); exists {
+			original[k] = &v
 		} else {
-			envVars[k] = nil
+			original[k] = nil
 		}
+	}
+	for k := range set {
+		record(k)
+	}
+	for _, k := range unset {
+		record(k)
+	}
+	ginkgo.DeferCleanup(restoreEnv, original)
+	for k, v := range set {
 		gomega.Expect(os.Setenv(k, v)).To(gomega.Succeed())
 	}
+	for _, k := range unset {
+		gomega.Expect(os.Unsetenv(k)).To(gomega.Succeed())
+	}
+}
+
+func restoreEnv(original map[string]*string) error {
+	var result error
+	for k, v := range original {
+		var err error
+		if v == nil {
+			err = os.Unsetenv(k)
+		} else {
+			err = os.Setenv(k, *v)
+		}
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	return result
 }
 
+// HaveHeader is a Gomega matcher to ensure an HTTP response or request
+// has a header with the given key, and its value passes the given matcher
+// (or is equal to the given value).
+// The actual can be an *httptest.ResponseRecorder, *http.Response, or *http.Request.
+// If the header is missing, the names of the headers that are present are printed.
+//
+// When negated, HaveHeader succeeds if the header is missing,
+// or its value does not pass the matcher.
+// To assert a header is not present at all, use HaveNoHeader.
 func HaveHeader(key string, m interface{}) gomega.OmegaMatcher {
 	return &matchers.HaveHeaderMatcher{Key: key, Inner: internal.CoerceToMatcher(m)}
 }
 
+// HaveNoHeader succeeds if an HTTP response or request does not have a header with the given key.
+// A header that is present with an empty value fails the matcher.
+// Use it for headers that must never be sent, like debug or server version headers.
+//
+//	Expect(rr).To(HaveNoHeader("X-Debug-Token"))
+func HaveNoHeader(key string) gomega.OmegaMatcher {
+	return &matchers.HaveHeaderMatcher{Key: key, Absent: true}
+}
+
+// HaveHeaderValues is like HaveHeader, but matches against every value of the header
+// as a []string, rather than just the first.
+// Use it for headers that can appear more than once, like Set-Cookie, Vary, or Link.
+// If the header is missing, the values are an empty slice.
+//
+//	Expect(rr).To(HaveHeaderValues("Vary", ConsistOf("Accept", "Origin")))
+//	Expect(rr).To(HaveHeaderValues("Set-Cookie", ContainElement(HavePrefix("session="))))
+func HaveHeaderValues(key string, m interface{}) gomega.OmegaMatcher {
+	return &matchers.HaveHeaderValuesMatcher{Key: key, Inner: internal.CoerceToMatcher(m)}
+}
+
+// HaveHeaders succeeds if every header in the map passes HaveHeader
+// with the corresponding value or matcher.
+// Unlike combining several HaveHeader matchers with SatisfyAll,
+// every header that does not match is reported in the failure message.
+//
+//	Expect(rr).To(HaveHeaders(map[string]interface{}{
+//	  "Content-Type":           "application/json",
+//	  "Cache-Control":          ContainSubstring("no-store"),
+//	  "X-Content-Type-Options": "nosniff",
+//	}))
+func HaveHeaders(headers map[string]interface{}) gomega.OmegaMatcher {
+	ms := make(map[string]types.GomegaMatcher, len(headers))
+	for k, v := range headers {
+		ms[k] = internal.CoerceToMatcher(v)
+	}
+	return &matchers.HaveHeadersMatcher{Headers: ms}
+}
+
+// HaveCookie succeeds if an HTTP response sets a cookie with the given name
+// (using the Set-Cookie header), and the parsed *http.Cookie passes the given matcher.
+// For an *http.Request, the cookies in the Cookie header are used.
+// If the cookie is set more than once, the last one is used.
+// Use MatchPtrField to check cookie attributes:
+//
+//	Expect(rr).To(HaveCookie("session", SatisfyAll(
+//	  MatchPtrField("Value", Not(BeEmpty())),
+//	  MatchPtrField("HttpOnly", true),
+//	  MatchPtrField("SameSite", http.SameSiteStrictMode),
+//	)))
+func HaveCookie(name string, m interface{}) gomega.OmegaMatcher {
+	return &matchers.HaveCookieMatcher{Name: name, Inner: internal.CoerceToMatcher(m)}
+}
+
+// HaveJsonBody is a Gomega matcher to ensure the body of an HTTP response or request
+// is valid JSON, and the decoded body passes the given matcher (or is equal to the given value).
+// The actual can be an *httptest.ResponseRecorder, *http.Response, or *http.Request.
+// The body of a response or request is replaced after it is read,
+// so it can be read again by other matchers or the test.
+// Bodies with a gzip or deflate Content-Encoding are decoded before matching
+// (this is true for all the body matchers); other encodings are an error.
+//
+// If the body is not valid JSON, the match errors, so the assertion fails even when negated.
+// This way, Expect(rr).ToNot(HaveJsonBody(HaveKey("debug"))) cannot pass on an HTML error page.
 func HaveJsonBody(m interface{}) gomega.OmegaMatcher {
 	return &matchers.HaveJsonBodyMatcher{Inner: internal.CoerceToMatcher(m)}
 }
 
+// HaveJsonBodyAs is like HaveJsonBody, but decodes the body into target,
+// which must be a non-nil pointer, like a pointer to a response struct.
+// The matcher is passed target, so can use MatchPtrField,
+// and target can be used by the test after the match.
+//
+//	var body UserResponse
+//	Expect(rr).To(HaveJsonBodyAs(&body, MatchPtrField("ID", 5)))
+//	Expect(body.Name).To(Equal("Rob"))
+//
+// If decoding fails, the match errors (so the assertion fails even when negated),
+// and the error and the part of the body where it failed are printed.
+func HaveJsonBodyAs(target interface{}, m interface{}) gomega.OmegaMatcher {
+	return &matchers.HaveJsonBodyAsMatcher{Target: target, Inner: internal.CoerceToMatcher(m)}
+}
+
+// HaveStrictJsonBodyAs is like HaveJsonBodyAs, but errors if the body has
+// object keys that do not match a field in target.
+// Use it to catch API drift, like fields that were added or renamed.
+func HaveStrictJsonBodyAs(target interface{}, m interface{}) gomega.OmegaMatcher {
+	return &matchers.HaveJsonBodyAsMatcher{Target: target, Inner: internal.CoerceToMatcher(m), DisallowUnknownFields: true}
+}
+
+// HaveXMLBody is like HaveJsonBodyAs, but for XML bodies.
+// The Content-Type must be application/xml, text/xml, or end with +xml,
+// and any charset must be UTF-8.
+// The body is decoded with encoding/xml into target, which must be a non-nil pointer,
+// and target is matched against m.
+// Like HaveJsonBody, a wrong Content-Type or a decode error makes the match error,
+// so the assertion fails even when negated.
+//
+//	var user UserXML
+//	Expect(rr).To(HaveXMLBody(&user, MatchPtrField("Name", "Rob")))
+func HaveXMLBody(target interface{}, m interface{}) gomega.OmegaMatcher {
+	return &matchers.HaveXMLBodyMatcher{Target: target, Inner: internal.CoerceToMatcher(m)}
+}
+
+// HaveFormBody matches the url.Values of an application/x-www-form-urlencoded body
+// (the Content-Type is checked) against m.
+// A wrong Content-Type or an invalid body makes the match error, like HaveXMLBody.
+//
+//	Expect(req).To(HaveFormBody(HaveKeyWithValue("name", []string{"Rob"})))
+func HaveFormBody(m interface{}) gomega.OmegaMatcher {
+	return &matchers.HaveFormBodyMatcher{Inner: internal.CoerceToMatcher(m)}
+}
+
+// HaveTextBody matches the body as a string against m.
+// The Content-Type must be text/*, like text/plain or text/html,
+// and the body must be valid UTF-8 (and any charset must be UTF-8),
+// or the match errors, like HaveXMLBody.
+//
+//	Expect(rr).To(HaveTextBody(ContainSubstring("pong")))
+func HaveTextBody(m interface{}) gomega.OmegaMatcher {
+	return &matchers.HaveTextBodyMatcher{Inner: internal.CoerceToMatcher(m)}
+}
+
+// BeCompressedWith succeeds if the Content-Encoding of an *httptest.ResponseRecorder,
+// *http.Response, or *http.Request includes encoding (like "gzip" or "deflate"),
+// and the body is validly encoded with it.
+// Use it to assert that compression middleware ran.
+// The body-reading matchers, like HaveJsonBody, decode gzip and deflate bodies automatically.
+//
+//	Expect(rr).To(BeCompressedWith("gzip"))
+//	Expect(rr).To(HaveJsonBody(HaveKey("id")))
+func BeCompressedWith(encoding string) gomega.OmegaMatcher {
+	return &matchers.BeCompressedWithMatcher{Encoding: encoding}
+}
+
+// MatchJSONSchema succeeds if the actual conforms to the JSON Schema schema,
+// which can be a JSON string or []byte, or a value that marshals to the schema
+// (like a map[string]interface{}).
+// The actual can be decoded JSON (so it can be used inside HaveJsonBody),
+// JSON text as a []byte or json.RawMessage, a *httptest.ResponseRecorder, *http.Response,
+// or *http.Request, or any value that marshals to JSON.
+// A string is an instance, not JSON text, so a JSON string body works inside HaveJsonBody.
+// If the actual cannot be decoded as JSON, the match errors, so the assertion fails even when negated.
+//
+//	schema, _ := os.ReadFile("testdata/user.schema.json")
+//	Expect(rr).To(MatchJSONSchema(schema))
+//
+// A subset of draft 2020-12 is supported: type, enum, required, properties,
+// additionalProperties, items, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// minLength, maxLength, pattern, minItems, maxItems, and $ref within the schema document
+// (like "#/$defs/user"). Other keywords are ignored.
+// Every violation is listed in the failure message, with the JSON pointer to the failing value.
+func MatchJSONSchema(schema interface{}) gomega.OmegaMatcher {
+	return &matchers.MatchJSONSchemaMatcher{Schema: schema}
+}
+
+// ConformToOpenAPI succeeds if an *httptest.ResponseRecorder or *http.Response
+// matches a documented response of the operation with the given operationId
+// in the OpenAPI 3 document (YAML or JSON) at specPath.
+// The status code must be documented (exactly, like "404", by range, like "4XX", or with "default"),
+// and the response is checked against it:
+// the Content-Type must be one of the documented media types,
+// required headers must be present and header values match their schemas,
+// and JSON bodies must match their schema (see MatchJSONSchema for the supported keywords).
+//
+//	Expect(rr).To(ConformToOpenAPI("../api/openapi.yaml", "getUser"))
+//
+// Use it alongside HaveResponseCode to catch status codes a handler returns
+// that are not in the API contract.
+func ConformToOpenAPI(specPath, operationID string) gomega.OmegaMatcher {
+	return &matchers.ConformToOpenAPIMatcher{SpecPath: specPath, OperationID: operationID}
+}
+
 // HaveResponseCode is a Gomega matcher to ensure an
-// *httptest.ResponseRecorder has the expected response code.
-// If it does not, the actual code and body are printed
+// *httptest.ResponseRecorder or *http.Response has the expected response code.
+// codeOrMatcher can be an int code, a class like Status2xx,
+// a status name like "Not Found" (see http.StatusText), or a matcher for the int code.
+//
+//	Expect(rr).To(HaveResponseCode(Status2xx))
+//	Expect(rr).To(HaveResponseCode("Unprocessable Entity"))
+//
+// If it does not match, the actual code, headers, and body are printed
 // (the body is really useful information when tests fail).
+// JSON bodies are pretty-printed, and long bodies are truncated to matchers.MaxFailureBodyLen.
 func HaveResponseCode(codeOrMatcher interface{}) gomega.OmegaMatcher {
 	return &matchers.HaveResponseCodeMatcher{CodeOrMatcher: codeOrMatcher}
 }
 
+// Status classes for HaveResponseCode.
+const (
+	Status1xx = matchers.Status1xx
+	Status2xx = matchers.Status2xx
+	Status3xx = matchers.Status3xx
+	Status4xx = matchers.Status4xx
+	Status5xx = matchers.Status5xx
+)
+
+// HaveProblemDetails succeeds if an *httptest.ResponseRecorder or *http.Response
+// is an RFC 7807 application/problem+json error response.
+// status is matched like HaveResponseCode, and type and title are values or matchers
+// for those members (nil to not check them; a missing type is "about:blank").
+// extensions are alternating member names and values (or matchers);
+// since they are decoded from JSON, values are compared with BeEquivalentTo.
+//
+//	Expect(rr).To(HaveProblemDetails(422, "https://example.com/probs/invalid", "Invalid input",
+//	  "errors", HaveLen(2)))
+//
+// It also checks the title member is present, the standard members have the right types,
+// and the status member (if present) is the same as the response status.
+func HaveProblemDetails(status, problemType, title interface{}, extensions ...interface{}) gomega.OmegaMatcher {
+	return &matchers.HaveProblemDetailsMatcher{Status: status, Type: problemType, Title: title, Extensions: extensions}
+}
+
+// RedirectTo succeeds if an *httptest.ResponseRecorder or *http.Response
+// has a 3xx status code and a Location header matching location,
+// which can be a string or matcher.
+//
+//	Expect(rr).To(RedirectTo("/login"))
+//	Expect(rr).To(RedirectTo(HavePrefix("https://accounts.example.com/")))
+//
+// For an *http.Response with a Request, relative locations are resolved against the request URL,
+// so the matcher sees the absolute URL (and string locations are also resolved before comparing).
+// The failure message says whether the status code or the location did not match.
+func RedirectTo(location interface{}) gomega.OmegaMatcher {
+	return &matchers.RedirectToMatcher{Location: location}
+}
+
+// RedirectToWithCode is like RedirectTo, but requires the exact status code,
+// like http.StatusMovedPermanently or http.StatusTemporaryRedirect.
+func RedirectToWithCode(code int, location interface{}) gomega.OmegaMatcher {
+	return &matchers.RedirectToMatcher{Location: location, Code: code}
+}
+
 // AtEvery succeeds when every element in a slice matches the given matcher.
 // Used to assert an expectation against every element in a collection.
 func AtEvery(m interface{}) gomega.OmegaMatcher {
@@ -108,6 +454,23 @@ func AtKey(key interface{}, m interface{}) gomega.OmegaMatcher {
 	return &matchers.AtKeyMatcher{Key: key, Matcher: internal.CoerceToMatcher(m)}
 }
 
+// AtPath succeeds when the value at the given path matches the given matcher.
+// It is a shorter way to write nested AtKey and AtIndex matchers,
+// and works with decoded JSON, maps, slices, and structs
+// (struct fields are found by their json tag or Go name).
+//
+//	Expect(rr).To(HaveJsonBody(AtPath("data.items[0].id", BeEquivalentTo(5))))
+//
+// A "[*]" segment selects every element of a slice or map (maps in key order),
+// and the matcher is matched against a slice of the values found:
+//
+//	Expect(rr).To(HaveJsonBody(AtPath("data.items[*].id", ConsistOf("a", "b"))))
+//
+// If part of the path does not exist, the failure says which segment was missing.
+func AtPath(path string, m interface{}) gomega.OmegaMatcher {
+	return &matchers.AtPathMatcher{Path: path, Matcher: internal.CoerceToMatcher(m)}
+}
+
 // MatchLen matches the length of a collection against a matcher.
 // It's like HaveLen, but allows a dynamic length.
 // HaveLen(2) would be equivalent to MatchLen(Equal(2)).
@@ -159,3 +522,22 @@ func MatchPtrField(name string, m interface{}) gomega.OmegaMatcher {
 func NotError() gomega.OmegaMatcher {
 	return &matchers.NotErrorMatcher{}
 }
+
+// HaveExitedWith succeeds if the actual has exited with the given code,
+// or a code that passes the given matcher.
+// The actual must have an ExitCode() int method that returns -1 if it has not exited,
+// like *golangal.Stdio, *os.ProcessState, and *gexec.Session.
+//
+//	stdio.Run(main)
+//	Expect(stdio).To(HaveExitedWith(2))
+func HaveExitedWith(codeOrMatcher interface{}) gomega.OmegaMatcher {
+	return &matchers.HaveExitedWithMatcher{CodeOrMatcher: codeOrMatcher}
+}
+
+// BeWithinDurationOf succeeds if the actual time.Time is within d of t, before or after.
+// It is like BeTemporally("~", t, d), but the failure message includes how far apart the times are.
+//
+//	Expect(token.ExpiresAt).To(BeWithinDurationOf(clock.Now().Add(time.Hour), time.Second))
+func BeWithinDurationOf(t time.Time, d time.Duration) gomega.OmegaMatcher {
+	return &matchers.BeWithinDurationOfMatcher{Expected: t, Duration: d}
+}
@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/rgalanakis/golangal"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -13,11 +14,73 @@ func TestGolangal(t *testing.T) {
 	RunSpecs(t, "golangal package Suite")
 }
 
+var suiteTempDir = golangal.SuiteTempDir()
+
 var _ = Describe("EachTempDir and SuiteTempDir", func() {
 	tempdir := golangal.EachTempDir()
 
 	It("creates a temporary directory", func() {
 		Expect(tempdir()).To(HavePrefix(os.TempDir()))
+		Expect(tempdir()).To(BeADirectory())
+	})
+
+	var previous string
+	It("removes the directory after the test (record)", func() {
+		previous = tempdir()
+	})
+
+	It("removes the directory after the test (check)", func() {
+		Expect(previous).ToNot(BeEmpty())
+		Expect(previous).ToNot(BeADirectory())
+		Expect(tempdir()).ToNot(Equal(previous))
+	})
+
+	Describe("ContainerTempDir", Ordered, func() {
+		containerdir := golangal.ContainerTempDir()
+
+		It("creates a temporary directory", func() {
+			Expect(containerdir()).To(HavePrefix(os.TempDir()))
+			Expect(containerdir()).To(BeADirectory())
+			previous = containerdir()
+		})
+
+		It("shares the directory across tests in the container", func() {
+			Expect(containerdir()).To(Equal(previous))
+		})
+	})
+
+	It("removes the container directory after the container", func() {
+		Expect(previous).ToNot(BeADirectory())
+	})
+
+	It("creates a suite temporary directory", func() {
+		Expect(suiteTempDir()).To(HavePrefix(os.TempDir()))
+		Expect(suiteTempDir()).To(BeADirectory())
+	})
+
+	Describe("TempDir", Ordered, func() {
+		var allDir, testDir string
+		BeforeAll(func() {
+			allDir = golangal.TempDir()
+		})
+
+		It("creates directories that are cleaned up with the current node", func() {
+			Expect(allDir).To(BeADirectory())
+			testDir = golangal.TempDir()
+			Expect(testDir).To(HavePrefix(os.TempDir()))
+			Expect(testDir).To(BeADirectory())
+			Expect(golangal.TempDir()).ToNot(Equal(testDir))
+		})
+
+		It("removes directories created in a test after the test", func() {
+			Expect(testDir).ToNot(BeADirectory())
+			Expect(allDir).To(BeADirectory())
+			previous = allDir
+		})
+	})
+
+	It("removes directories created in a BeforeAll after the container", func() {
+		Expect(previous).ToNot(BeADirectory())
 	})
 })
 
@@ -53,3 +116,86 @@ var _ = Describe("Envvars", func() {
 		Expect(os.Getenv("USER")).To(Equal(originalUserValue))
 	})
 })
+
+var _ = Describe("SetEnv, UnsetEnv, SetEnvs, and LoadEnvFile", func() {
+	rootdir := golangal.EachFileTree(golangal.FileTree{
+		".env": `# comment
+GOLANGAL_A=1
+export GOLANGAL_B='single # quoted'
+GOLANGAL_C="double\nquoted"
+GOLANGAL_D=unquoted # comment
+`,
+		"bad.env": "GOLANGAL_A",
+	})
+
+	BeforeEach(func() {
+		golangal.SetEnv("GOLANGAL_EXISTING", "orig")
+	})
+
+	It("sets a variable set multiple times (set)", func() {
+		golangal.SetEnv("GOLANGAL_EXISTING", "x")
+		golangal.SetEnv("GOLANGAL_EXISTING", "y")
+		Expect(os.Getenv("GOLANGAL_EXISTING")).To(Equal("y"))
+	})
+
+	It("restores a variable set multiple times (check)", func() {
+		Expect(os.Getenv("GOLANGAL_EXISTING")).To(Equal("orig"))
+	})
+
+	It("unsets a variable (unset)", func() {
+		golangal.UnsetEnv("GOLANGAL_EXISTING")
+		_, exists := os.LookupEnv("GOLANGAL_EXISTING")
+		Expect(exists).To(BeFalse())
+	})
+
+	It("restores an unset variable (check)", func() {
+		Expect(os.Getenv("GOLANGAL_EXISTING")).To(Equal("orig"))
+	})
+
+	It("sets a map of variables", func() {
+		golangal.SetEnvs(map[string]string{"GOLANGAL_A": "a", "GOLANGAL_EXISTING": "b"})
+		Expect(os.Getenv("GOLANGAL_A")).To(Equal("a"))
+		Expect(os.Getenv("GOLANGAL_EXISTING")).To(Equal("b"))
+	})
+
+	It("loads variables from a dotenv file", func() {
+		golangal.LoadEnvFile(filepath.Join(rootdir(), ".env"))
+		Expect(os.Getenv("GOLANGAL_A")).To(Equal("1"))
+		Expect(os.Getenv("GOLANGAL_B")).To(Equal("single # quoted"))
+		Expect(os.Getenv("GOLANGAL_C")).To(Equal("double\nquoted"))
+		Expect(os.Getenv("GOLANGAL_D")).To(Equal("unquoted"))
+	})
+
+	It("fails for an invalid dotenv file", func() {
+		failures := InterceptGomegaFailures(func() {
+			golangal.LoadEnvFile(filepath.Join(rootdir(), "bad.env"))
+		})
+		Expect(failures).To(ConsistOf(ContainSubstring(`line 1: expected KEY=VALUE, got "GOLANGAL_A"`)))
+	})
+
+	It("restores loaded variables (check)", func() {
+		for _, k := range []string{"GOLANGAL_A", "GOLANGAL_B", "GOLANGAL_C", "GOLANGAL_D"} {
+			_, exists := os.LookupEnv(k)
+			Expect(exists).To(BeFalse(), k)
+		}
+	})
+
+	Describe("in a BeforeAll", Ordered, func() {
+		BeforeAll(func() {
+			golangal.SetEnv("GOLANGAL_CONTAINER", "x")
+		})
+
+		It("sets the variable", func() {
+			Expect(os.Getenv("GOLANGAL_CONTAINER")).To(Equal("x"))
+		})
+
+		It("keeps the variable across tests", func() {
+			Expect(os.Getenv("GOLANGAL_CONTAINER")).To(Equal("x"))
+		})
+	})
+
+	It("restores the variable after the container", func() {
+		_, exists := os.LookupEnv("GOLANGAL_CONTAINER")
+		Expect(exists).To(BeFalse())
+	})
+})
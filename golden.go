@@ -0,0 +1,99 @@
+package golangal
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/rgalanakis/golangal/matchers"
+)
+
+// UpdateGoldenFilesEnv is the environment variable that,
+// when set to a true value like "1" or "true",
+// causes MatchGoldenFile and MatchSnapshot to write golden files rather than compare against them.
+//
+//	GOLANGAL_UPDATE=1 go test ./...
+const UpdateGoldenFilesEnv = "GOLANGAL_UPDATE"
+
+// GoldenDir is the directory, relative to the package being tested, that golden files are stored in.
+const GoldenDir = "testdata"
+
+// MatchGoldenFile succeeds if the actual value matches the contents of the golden file
+// at path (relative to GoldenDir, unless it is absolute).
+// The actual value can be a string, []byte, *httptest.ResponseRecorder or *http.Response (the body is used),
+// or any other value, which is encoded as indented JSON.
+// JSON objects and arrays are normalized so key order and whitespace do not matter.
+// On failure, a unified diff of the golden file and actual value is printed.
+//
+// If the GOLANGAL_UPDATE environment variable is set, the golden file is written
+// with the actual value instead, and the matcher always succeeds.
+//
+//	Expect(RenderTemplate()).To(MatchGoldenFile("template.html"))
+func MatchGoldenFile(path string) gomega.OmegaMatcher {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(GoldenDir, path)
+	}
+	return &matchers.MatchGoldenFileMatcher{
+		Path:       path,
+		Update:     updateGoldenFiles(),
+		UpdateHint: fmt.Sprintf(" (set %s=1 to update)", UpdateGoldenFilesEnv),
+	}
+}
+
+// MatchSnapshot is like MatchGoldenFile, except the golden file is named
+// after the full text of the current test, under GoldenDir/snapshots.
+// If MatchSnapshot is used more than once in the same test,
+// each subsequent snapshot gets a numbered suffix.
+//
+//	It("renders the user", func() {
+//	  Expect(rr).To(HaveJsonBody(MatchSnapshot()))
+//	})
+func MatchSnapshot() gomega.OmegaMatcher {
+	report := ginkgo.CurrentSpecReport()
+	name := snapshotName(report.FullText())
+	if report.StartTime != snapshotCounter.start || report.FullText() != snapshotCounter.spec {
+		snapshotCounter.start = report.StartTime
+		snapshotCounter.spec = report.FullText()
+		snapshotCounter.count = 0
+	}
+	snapshotCounter.count++
+	if snapshotCounter.count > 1 {
+		name += "-" + strconv.Itoa(snapshotCounter.count)
+	}
+	return MatchGoldenFile(filepath.Join("snapshots", name+".snap"))
+}
+
+var snapshotCounter struct {
+	spec  string
+	start time.Time
+	count int
+}
+
+var invalidSnapshotChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+const maxSnapshotNameLen = 120
+
+func snapshotName(fullText string) string {
+	name := strings.Trim(invalidSnapshotChars.ReplaceAllString(fullText, "_"), "_.")
+	if name == "" {
+		name = "snapshot"
+	}
+	if len(name) > maxSnapshotNameLen {
+		// Keep long names unique by replacing the end with a hash of the full text.
+		sum := sha1.Sum([]byte(fullText))
+		name = fmt.Sprintf("%s-%x", name[:maxSnapshotNameLen-9], sum[:4])
+	}
+	return name
+}
+
+func updateGoldenFiles() bool {
+	update, _ := strconv.ParseBool(os.Getenv(UpdateGoldenFilesEnv))
+	return update
+}
@@ -0,0 +1,61 @@
+package golangal_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rgalanakis/golangal"
+	"github.com/rgalanakis/golangal/matchers"
+)
+
+var _ = Describe("MatchGoldenFile and MatchSnapshot", func() {
+	addEnvVar := golangal.EnvVars()
+
+	It("looks for golden files under testdata", func() {
+		matcher := golangal.MatchGoldenFile("x.txt").(*matchers.MatchGoldenFileMatcher)
+		Expect(matcher.Path).To(Equal(filepath.Join("testdata", "x.txt")))
+		Expect(matcher.Update).To(BeFalse())
+	})
+
+	It("uses absolute paths as-is", func() {
+		matcher := golangal.MatchGoldenFile("/x.txt").(*matchers.MatchGoldenFileMatcher)
+		Expect(matcher.Path).To(Equal("/x.txt"))
+	})
+
+	It("updates golden files if the env var is set", func() {
+		addEnvVar("GOLANGAL_UPDATE", "1")
+		matcher := golangal.MatchGoldenFile("x.txt").(*matchers.MatchGoldenFileMatcher)
+		Expect(matcher.Update).To(BeTrue())
+	})
+
+	It("names snapshots after the test: with (special) chars", func() {
+		first := golangal.MatchSnapshot().(*matchers.MatchGoldenFileMatcher)
+		second := golangal.MatchSnapshot().(*matchers.MatchGoldenFileMatcher)
+		Expect(first.Path).To(Equal(filepath.Join("testdata", "snapshots",
+			"MatchGoldenFile_and_MatchSnapshot_names_snapshots_after_the_test_with_special_chars.snap")))
+		Expect(second.Path).To(Equal(filepath.Join("testdata", "snapshots",
+			"MatchGoldenFile_and_MatchSnapshot_names_snapshots_after_the_test_with_special_chars-2.snap")))
+	})
+
+	It("restarts snapshot numbering in each test", func() {
+		first := golangal.MatchSnapshot().(*matchers.MatchGoldenFileMatcher)
+		Expect(first.Path).To(HaveSuffix("numbering_in_each_test.snap"))
+	})
+
+	It("writes and matches snapshots", func() {
+		// Write snapshots into a temporary working directory, not the package's testdata.
+		wd := golangal.TempDir()
+		golangal.ChangeWorkDir(wd)
+		path := filepath.Join(wd, "testdata", "snapshots", "MatchGoldenFile_and_MatchSnapshot_writes_and_matches_snapshots.snap")
+
+		addEnvVar("GOLANGAL_UPDATE", "true")
+		Expect(map[string]int{"b": 2, "a": 1}).To(golangal.MatchSnapshot())
+		Expect(ioutil.ReadFile(path)).To(BeEquivalentTo("{\n  \"a\": 1,\n  \"b\": 2\n}\n"))
+
+		addEnvVar("GOLANGAL_UPDATE", "")
+		Expect(`{"a":1,"b":2}`).To(golangal.MatchGoldenFile(path))
+		Expect(`{"a":2}`).ToNot(golangal.MatchGoldenFile(path))
+	})
+})
@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+// Beyond this many line comparisons, the diff falls back to
+// removing every line of a and adding every line of b.
+const maxDiffCells = 4000000
+
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// UnifiedDiff returns a unified diff of a and b, with the given names in the header.
+// It returns an empty string if a and b are equal.
+func UnifiedDiff(aName, bName, a, b string) string {
+	if a == b {
+		return ""
+	}
+	aLines, bLines := splitLines(a), splitLines(b)
+	ops := diffLines(aLines, bLines)
+
+	bld := &strings.Builder{}
+	fmt.Fprintf(bld, "--- %s\n+++ %s\n", aName, bName)
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+		// Find the end of this hunk: the last change that is within 2*context of the next.
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i
+			} else if i-end > 2*diffContext {
+				break
+			}
+		}
+		hunkStart := start - diffContext
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+		hunkEnd := end + diffContext + 1
+		if hunkEnd > len(ops) {
+			hunkEnd = len(ops)
+		}
+		aStart, bStart := lineNumbers(ops[:hunkStart])
+		aLen, bLen := lineNumbers(ops[hunkStart:hunkEnd])
+		fmt.Fprintf(bld, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+		for _, op := range ops[hunkStart:hunkEnd] {
+			bld.WriteByte(op.kind)
+			bld.WriteString(op.line)
+			bld.WriteByte('\n')
+		}
+		start = hunkEnd
+	}
+	return bld.String()
+}
+
+const noNewlineMarker = "\n\\ No newline at end of file"
+
+// splitLines splits s into lines.
+// If s does not end with a newline, the last line gets a marker like diff(1) prints,
+// so it differs from the same line with a newline, and the diff shows why.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	if !strings.HasSuffix(s, "\n") {
+		lines[len(lines)-1] += noNewlineMarker
+	}
+	return lines
+}
+
+// diffLines computes a line-based edit script using the longest common subsequence.
+func diffLines(a, b []string) []diffOp {
+	ops := make([]diffOp, 0, len(a)+len(b))
+	if len(a)*len(b) > maxDiffCells {
+		for _, l := range a {
+			ops = append(ops, diffOp{'-', l})
+		}
+		for _, l := range b {
+			ops = append(ops, diffOp{'+', l})
+		}
+		return ops
+	}
+	// lcs[i][j] is the length of the LCS of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+func lineNumbers(ops []diffOp) (aCount, bCount int) {
+	for _, op := range ops {
+		if op.kind != '+' {
+			aCount++
+		}
+		if op.kind != '-' {
+			bCount++
+		}
+	}
+	return
+}
+
+func hunkRange(before, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, length)
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseDotEnv parses dotenv-formatted data into a map.
+// Lines are in the form KEY=VALUE, optionally prefixed with 'export '.
+// Blank lines and lines starting with # are ignored.
+// Values can be single-quoted (taken literally) or double-quoted
+// (supporting \n, \t, \", and \\ escapes). Unquoted values
+// are trimmed and can have a trailing ' #comment'.
+func ParseDotEnv(r io.Reader) (map[string]string, error) {
+	result := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		eq := strings.Index(line, "=")
+		if eq <= 0 {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE, got %q", lineNum, line)
+		}
+		key := strings.TrimSpace(line[:eq])
+		value, err := parseDotEnvValue(strings.TrimSpace(line[eq+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNum, err)
+		}
+		result[key] = value
+	}
+	return result, scanner.Err()
+}
+
+func parseDotEnvValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	switch raw[0] {
+	case '\'':
+		end := strings.Index(raw[1:], "'")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated single-quoted value %s", raw)
+		}
+		return raw[1 : end+1], nil
+	case '"':
+		bld := &strings.Builder{}
+		for i := 1; i < len(raw); i++ {
+			c := raw[i]
+			switch {
+			case c == '"':
+				return bld.String(), nil
+			case c == '\\' && i+1 < len(raw):
+				i++
+				switch raw[i] {
+				case 'n':
+					bld.WriteByte('\n')
+				case 't':
+					bld.WriteByte('\t')
+				case 'r':
+					bld.WriteByte('\r')
+				default:
+					bld.WriteByte(raw[i])
+				}
+			default:
+				bld.WriteByte(c)
+			}
+		}
+		return "", fmt.Errorf("unterminated double-quoted value %s", raw)
+	default:
+		if idx := strings.Index(raw, " #"); idx >= 0 {
+			raw = raw[:idx]
+		}
+		return strings.TrimSpace(raw), nil
+	}
+}
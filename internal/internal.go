@@ -8,3 +8,13 @@ func CoerceToMatcher(i interface{}) gomega.OmegaMatcher {
 	}
 	return gomega.Equal(i)
 }
+
+// CoerceToEquivalentMatcher is like CoerceToMatcher, but uses BeEquivalentTo,
+// for values whose type is not known exactly, like decoded JSON numbers (always float64)
+// or slog attributes (all integers are int64).
+func CoerceToEquivalentMatcher(i interface{}) gomega.OmegaMatcher {
+	if m, ok := i.(gomega.OmegaMatcher); ok {
+		return m
+	}
+	return gomega.BeEquivalentTo(i)
+}
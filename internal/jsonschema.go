@@ -0,0 +1,421 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SchemaViolation is a place where an instance does not conform to a JSON Schema.
+type SchemaViolation struct {
+	// Pointer is the JSON pointer to the failing value, as a URI fragment like "#/items/0/id".
+	Pointer string
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	return v.Pointer + ": " + v.Message
+}
+
+// SchemaValidator validates decoded JSON against a subset of JSON Schema draft 2020-12:
+// type, enum, required, properties, additionalProperties, items,
+// minimum, maximum, exclusiveMinimum, exclusiveMaximum, minLength, maxLength, pattern,
+// minItems, maxItems, boolean schemas, and $ref to JSON pointers within Root.
+// Other keywords are ignored.
+// Patterns use Go's regexp syntax, which covers the common subset of ECMA-262.
+type SchemaValidator struct {
+	// Root is the document that $ref pointers like "#/$defs/user" are resolved against.
+	Root interface{}
+	// OpenAPI30 enables the OpenAPI 3.0 schema dialect:
+	// "nullable: true" allows null, and a boolean exclusiveMinimum or exclusiveMaximum
+	// makes minimum or maximum exclusive.
+	OpenAPI30 bool
+
+	patterns map[string]*regexp.Regexp
+	refs     map[string]bool
+}
+
+// Validate returns every violation of schema by instance.
+// Both must be decoded JSON (maps, slices, float64, string, bool, and nil).
+// An error is returned if the schema is invalid, like an unresolvable $ref.
+func (v *SchemaValidator) Validate(schema, instance interface{}) (violations []SchemaViolation, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			se, ok := r.(schemaError)
+			if !ok {
+				panic(r)
+			}
+			violations, err = nil, se
+		}
+	}()
+	v.refs = make(map[string]bool)
+	v.validate(schema, instance, "#", &violations)
+	return violations, nil
+}
+
+type schemaError string
+
+func (e schemaError) Error() string {
+	return string(e)
+}
+
+func invalidSchema(format string, args ...interface{}) {
+	panic(schemaError("invalid schema: " + fmt.Sprintf(format, args...)))
+}
+
+func (v *SchemaValidator) validate(schema, instance interface{}, ptr string, out *[]SchemaViolation) {
+	violate := func(format string, args ...interface{}) {
+		*out = append(*out, SchemaViolation{Pointer: ptr, Message: fmt.Sprintf(format, args...)})
+	}
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			violate("no value is allowed")
+		}
+		return
+	case map[string]interface{}:
+		if v.OpenAPI30 && instance == nil && s["nullable"] == true {
+			return
+		}
+		if ref, ok := s["$ref"]; ok {
+			v.validateRef(ref, instance, ptr, out)
+		}
+		if t, ok := s["type"]; ok && !matchesType(t, instance) {
+			violate("expected type %s, got %s", describeTypes(t), jsonType(instance))
+			// The remaining keywords would only produce confusing violations.
+			return
+		}
+		if enum, ok := s["enum"]; ok {
+			values, ok := enum.([]interface{})
+			if !ok {
+				invalidSchema("enum must be an array")
+			}
+			if !containsJSON(values, instance) {
+				violate("%s is not one of %s", jsonString(instance), jsonString(values))
+			}
+		}
+		switch inst := instance.(type) {
+		case map[string]interface{}:
+			v.validateObject(s, inst, ptr, out)
+		case []interface{}:
+			v.validateArray(s, inst, ptr, out)
+		case string:
+			validateString(v, s, inst, violate)
+		case float64:
+			if v.OpenAPI30 {
+				s = openAPI30Bounds(s)
+			}
+			validateNumber(s, inst, violate)
+		}
+	default:
+		invalidSchema("schema at %s must be an object or boolean", ptr)
+	}
+}
+
+func (v *SchemaValidator) validateRef(ref, instance interface{}, ptr string, out *[]SchemaViolation) {
+	refStr, ok := ref.(string)
+	if !ok || !strings.HasPrefix(refStr, "#") {
+		invalidSchema("only $ref to a JSON pointer within the document is supported, got %v", ref)
+	}
+	// A $ref seen again for the same instance location would recurse forever.
+	key := refStr + " " + ptr
+	if v.refs[key] {
+		invalidSchema("circular $ref %s", refStr)
+	}
+	v.refs[key] = true
+	defer delete(v.refs, key)
+	target, ok := ResolveJSONPointer(v.Root, strings.TrimPrefix(refStr, "#"))
+	if !ok {
+		invalidSchema("$ref %s not found", refStr)
+	}
+	v.validate(target, instance, ptr, out)
+}
+
+func (v *SchemaValidator) validateObject(s map[string]interface{}, inst map[string]interface{}, ptr string, out *[]SchemaViolation) {
+	if required, ok := s["required"]; ok {
+		names, ok := required.([]interface{})
+		if !ok {
+			invalidSchema("required must be an array")
+		}
+		for _, n := range names {
+			name, _ := n.(string)
+			if _, ok := inst[name]; !ok {
+				*out = append(*out, SchemaViolation{Pointer: ptr, Message: fmt.Sprintf("missing required property %q", name)})
+			}
+		}
+	}
+	props, _ := s["properties"].(map[string]interface{})
+	additional, hasAdditional := s["additionalProperties"]
+	keys := make([]string, 0, len(inst))
+	for k := range inst {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		childPtr := ptr + "/" + escapeJSONPointer(k)
+		if propSchema, ok := props[k]; ok {
+			v.validate(propSchema, inst[k], childPtr, out)
+		} else if hasAdditional {
+			if additional == false {
+				*out = append(*out, SchemaViolation{Pointer: ptr, Message: fmt.Sprintf("additional property %q is not allowed", k)})
+			} else {
+				v.validate(additional, inst[k], childPtr, out)
+			}
+		}
+	}
+}
+
+func (v *SchemaValidator) validateArray(s map[string]interface{}, inst []interface{}, ptr string, out *[]SchemaViolation) {
+	violate := func(format string, args ...interface{}) {
+		*out = append(*out, SchemaViolation{Pointer: ptr, Message: fmt.Sprintf(format, args...)})
+	}
+	if min, ok := schemaInt(s, "minItems"); ok && len(inst) < min {
+		violate("has %d items, fewer than minItems %d", len(inst), min)
+	}
+	if max, ok := schemaInt(s, "maxItems"); ok && len(inst) > max {
+		violate("has %d items, more than maxItems %d", len(inst), max)
+	}
+	if items, ok := s["items"]; ok {
+		for i, item := range inst {
+			v.validate(items, item, ptr+"/"+strconv.Itoa(i), out)
+		}
+	}
+}
+
+func validateString(v *SchemaValidator, s map[string]interface{}, inst string, violate func(string, ...interface{})) {
+	length := utf8.RuneCountInString(inst)
+	if min, ok := schemaInt(s, "minLength"); ok && length < min {
+		violate("length %d is less than minLength %d", length, min)
+	}
+	if max, ok := schemaInt(s, "maxLength"); ok && length > max {
+		violate("length %d is more than maxLength %d", length, max)
+	}
+	if p, ok := s["pattern"]; ok {
+		pattern, ok := p.(string)
+		if !ok {
+			invalidSchema("pattern must be a string")
+		}
+		if !v.compile(pattern).MatchString(inst) {
+			violate("%q does not match pattern %q", inst, pattern)
+		}
+	}
+}
+
+func validateNumber(s map[string]interface{}, inst float64, violate func(string, ...interface{})) {
+	if min, ok := schemaNumber(s, "minimum"); ok && inst < min {
+		violate("%v is less than minimum %v", inst, min)
+	}
+	if max, ok := schemaNumber(s, "maximum"); ok && inst > max {
+		violate("%v is more than maximum %v", inst, max)
+	}
+	if min, ok := schemaNumber(s, "exclusiveMinimum"); ok && inst <= min {
+		violate("%v is not more than exclusiveMinimum %v", inst, min)
+	}
+	if max, ok := schemaNumber(s, "exclusiveMaximum"); ok && inst >= max {
+		violate("%v is not less than exclusiveMaximum %v", inst, max)
+	}
+}
+
+// openAPI30Bounds converts OpenAPI 3.0 boolean exclusiveMinimum and exclusiveMaximum
+// to their JSON Schema equivalent.
+func openAPI30Bounds(s map[string]interface{}) map[string]interface{} {
+	_, minBool := s["exclusiveMinimum"].(bool)
+	_, maxBool := s["exclusiveMaximum"].(bool)
+	if !minBool && !maxBool {
+		return s
+	}
+	result := make(map[string]interface{}, len(s))
+	for k, v := range s {
+		result[k] = v
+	}
+	if minBool {
+		delete(result, "exclusiveMinimum")
+		if s["exclusiveMinimum"] == true && s["minimum"] != nil {
+			result["exclusiveMinimum"] = s["minimum"]
+			delete(result, "minimum")
+		}
+	}
+	if maxBool {
+		delete(result, "exclusiveMaximum")
+		if s["exclusiveMaximum"] == true && s["maximum"] != nil {
+			result["exclusiveMaximum"] = s["maximum"]
+			delete(result, "maximum")
+		}
+	}
+	return result
+}
+
+func (v *SchemaValidator) compile(pattern string) *regexp.Regexp {
+	if re, ok := v.patterns[pattern]; ok {
+		return re
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		invalidSchema("pattern %q: %v", pattern, err)
+	}
+	if v.patterns == nil {
+		v.patterns = make(map[string]*regexp.Regexp)
+	}
+	v.patterns[pattern] = re
+	return re
+}
+
+func schemaNumber(s map[string]interface{}, keyword string) (float64, bool) {
+	raw, ok := s[keyword]
+	if !ok {
+		return 0, false
+	}
+	n, ok := raw.(float64)
+	if !ok {
+		invalidSchema("%s must be a number", keyword)
+	}
+	return n, true
+}
+
+func schemaInt(s map[string]interface{}, keyword string) (int, bool) {
+	n, ok := schemaNumber(s, keyword)
+	return int(n), ok
+}
+
+func matchesType(t interface{}, instance interface{}) bool {
+	switch tt := t.(type) {
+	case string:
+		return matchesTypeName(tt, instance)
+	case []interface{}:
+		for _, name := range tt {
+			if s, ok := name.(string); ok && matchesTypeName(s, instance) {
+				return true
+			}
+		}
+		return false
+	}
+	invalidSchema("type must be a string or array")
+	return false
+}
+
+func matchesTypeName(name string, instance interface{}) bool {
+	actual := jsonType(instance)
+	switch name {
+	case "integer":
+		f, ok := instance.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		return actual == "number"
+	case "string", "boolean", "null", "object", "array":
+		return actual == name
+	}
+	invalidSchema("unknown type %q", name)
+	return false
+}
+
+func describeTypes(t interface{}) string {
+	if names, ok := t.([]interface{}); ok {
+		strs := make([]string, len(names))
+		for i, n := range names {
+			strs[i] = fmt.Sprint(n)
+		}
+		return strings.Join(strs, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func jsonType(instance interface{}) string {
+	switch instance.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", instance)
+}
+
+func containsJSON(values []interface{}, instance interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, instance) {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonString(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+// ResolveJSONPointer returns the value at the JSON pointer ptr (like "/$defs/user") in doc.
+// An empty ptr is the whole document.
+func ResolveJSONPointer(doc interface{}, ptr string) (interface{}, bool) {
+	if ptr == "" {
+		return doc, true
+	}
+	if !strings.HasPrefix(ptr, "/") {
+		return nil, false
+	}
+	cur := doc
+	for _, token := range strings.Split(ptr[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch c := cur.(type) {
+		case map[string]interface{}:
+			next, ok := c[token]
+			if !ok {
+				return nil, false
+			}
+			cur = next
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, false
+			}
+			cur = c[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// NormalizeJSON converts v into decoded JSON values (maps, slices, float64, string, bool, and nil).
+// Strings and byte slices are parsed as JSON; anything else is marshaled and unmarshaled.
+func NormalizeJSON(v interface{}) (interface{}, error) {
+	var b []byte
+	switch t := v.(type) {
+	case string:
+		b = []byte(t)
+	case []byte:
+		b = t
+	case json.RawMessage:
+		b = t
+	default:
+		var err error
+		if b, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	var result interface{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
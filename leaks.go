@@ -0,0 +1,119 @@
+package golangal
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+// GoroutineLeakTimeout is how long DetectGoroutineLeaks waits for new goroutines to exit
+// before failing the test.
+var GoroutineLeakTimeout = time.Second
+
+// DefaultGoroutineLeakIgnores are stack trace substrings of goroutines
+// that DetectGoroutineLeaks always ignores.
+var DefaultGoroutineLeakIgnores = []string{
+	"github.com/onsi/ginkgo/v2/internal.",
+	"os/signal.signal_recv",
+	"os/signal.loop",
+}
+
+// DetectGoroutineLeaks fails each test that starts goroutines which are still running
+// after the test and its cleanup have finished.
+// It records the running goroutines before each test, and after each test
+// waits up to GoroutineLeakTimeout for any new goroutines to exit.
+// The stack traces of leaked goroutines are printed in the failure,
+// and added to the test's report (for JSON and JUnit reports).
+//
+// Goroutines with a stack trace containing any of the ignore strings
+// (or DefaultGoroutineLeakIgnores) are not considered leaks.
+// Use it for long-lived goroutines like connection pools:
+//
+//	golangal.DetectGoroutineLeaks("net/http.(*persistConn)")
+//
+// The check happens in a DeferCleanup, so it runs after AfterEach nodes,
+// and after the cleanup of fixtures registered after it.
+// Call DetectGoroutineLeaks before other fixtures so their cleanup happens first.
+func DetectGoroutineLeaks(ignore ...string) {
+	ignore = append(append([]string(nil), DefaultGoroutineLeakIgnores...), ignore...)
+	ginkgo.BeforeEach(func() {
+		before := make(map[string]bool)
+		for _, g := range runningGoroutines() {
+			before[g.id] = true
+		}
+		ginkgo.DeferCleanup(func() {
+			var leaked []goroutine
+			deadline := time.Now().Add(GoroutineLeakTimeout)
+			for {
+				leaked = newGoroutines(before, ignore)
+				if len(leaked) == 0 || time.Now().After(deadline) {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			if len(leaked) == 0 {
+				return
+			}
+			stacks := make([]string, len(leaked))
+			for i, g := range leaked {
+				stacks[i] = g.stack
+			}
+			// The entry is for machine-readable reports; the failure message already has the stacks.
+			ginkgo.AddReportEntry("Leaked goroutines", strings.Join(stacks, "\n\n"),
+				ginkgo.ReportEntryVisibilityNever)
+			ginkgo.Fail(fmt.Sprintf("%d goroutine(s) leaked by this test:\n\n%s", len(leaked), strings.Join(stacks, "\n\n")))
+		})
+	})
+}
+
+type goroutine struct {
+	id    string
+	stack string
+}
+
+// runningGoroutines returns every goroutine except the current one.
+func runningGoroutines() []goroutine {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	traces := strings.Split(strings.TrimSpace(string(buf)), "\n\n")
+	result := make([]goroutine, 0, len(traces))
+	// The first trace is always the current goroutine.
+	for _, trace := range traces[1:] {
+		header := strings.SplitN(trace, " ", 3)
+		if len(header) < 3 || header[0] != "goroutine" {
+			continue
+		}
+		result = append(result, goroutine{id: header[1], stack: trace})
+	}
+	return result
+}
+
+func newGoroutines(before map[string]bool, ignore []string) []goroutine {
+	var result []goroutine
+	for _, g := range runningGoroutines() {
+		if before[g.id] || containsAny(g.stack, ignore) {
+			continue
+		}
+		result = append(result, g)
+	}
+	return result
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
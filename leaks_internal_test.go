@@ -0,0 +1,30 @@
+package golangal
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func leakForTest(ch chan struct{}) {
+	<-ch
+}
+
+var _ = Describe("goroutine leak detection", func() {
+	It("finds new goroutines that are not ignored", func() {
+		before := make(map[string]bool)
+		for _, g := range runningGoroutines() {
+			before[g.id] = true
+		}
+		Expect(newGoroutines(before, DefaultGoroutineLeakIgnores)).To(BeEmpty())
+
+		stop := make(chan struct{})
+		defer close(stop)
+		go leakForTest(stop)
+		Eventually(func() []goroutine {
+			return newGoroutines(before, DefaultGoroutineLeakIgnores)
+		}).Should(ConsistOf(
+			WithTransform(func(g goroutine) string { return g.stack }, ContainSubstring("golangal.leakForTest")),
+		))
+		Expect(newGoroutines(before, []string{"golangal.leakForTest"})).To(BeEmpty())
+	})
+})
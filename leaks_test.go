@@ -0,0 +1,37 @@
+package golangal_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/rgalanakis/golangal"
+)
+
+func blockUntilClosed(ch chan struct{}) {
+	<-ch
+}
+
+var _ = Describe("DetectGoroutineLeaks", func() {
+	golangal.DetectGoroutineLeaks("golangal_test.blockUntilClosed")
+
+	It("passes if goroutines exit after the test", func() {
+		go time.Sleep(50 * time.Millisecond)
+	})
+
+	It("passes if goroutines are stopped in cleanup", func() {
+		stop := make(chan struct{})
+		DeferCleanup(func() { close(stop) })
+		go func() {
+			<-stop
+		}()
+	})
+
+	stopIgnored := make(chan struct{})
+	It("ignores goroutines matching the ignore list", func() {
+		go blockUntilClosed(stopIgnored)
+	})
+
+	It("(stops ignored goroutines)", func() {
+		close(stopIgnored)
+	})
+})
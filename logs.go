@@ -0,0 +1,155 @@
+package golangal
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/rgalanakis/golangal/matchers"
+)
+
+// LogRecord is a log record captured by EachLogs.
+type LogRecord = matchers.LogRecord
+
+// LogRecorder is an in-memory slog.Handler that records every log record.
+// Create it with EachLogs.
+type LogRecorder struct {
+	mu      *sync.Mutex
+	records *[]LogRecord
+	attrs   []slog.Attr
+	groups  []string
+}
+
+// NewLogRecorder returns a new LogRecorder.
+// Usually you should use EachLogs, but NewLogRecorder is useful
+// for code that takes a *slog.Logger rather than using the default logger.
+func NewLogRecorder() *LogRecorder {
+	return &LogRecorder{mu: &sync.Mutex{}, records: &[]LogRecord{}}
+}
+
+// EachLogs returns a LogRecorder that is installed as the default slog handler before each test,
+// and captures output of the standard log package as INFO records.
+// The original slog and log defaults are restored after each test.
+// Use HaveLogged to make assertions about the records.
+//
+// Example:
+//
+//	logs := golangal.EachLogs()
+//	It("logs the failure", func() {
+//	  DoThing()
+//	  Expect(logs).To(HaveLogged(slog.LevelError, ContainSubstring("failed"), "attempt", 3))
+//	})
+func EachLogs() *LogRecorder {
+	recorder := NewLogRecorder()
+	ginkgo.BeforeEach(func() {
+		recorder.Reset()
+		origSlog := slog.Default()
+		origWriter, origFlags, origPrefix := log.Writer(), log.Flags(), log.Prefix()
+		ginkgo.DeferCleanup(func() {
+			slog.SetDefault(origSlog)
+			log.SetOutput(origWriter)
+			log.SetFlags(origFlags)
+			log.SetPrefix(origPrefix)
+		})
+		// Setting a non-default slog handler also sends standard log output to the handler.
+		slog.SetDefault(slog.New(recorder))
+		log.SetPrefix("")
+	})
+	return recorder
+}
+
+// Records returns every record that has been logged.
+func (r *LogRecorder) Records() []LogRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]LogRecord(nil), *r.records...)
+}
+
+// Reset removes all records.
+func (r *LogRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.records = nil
+}
+
+func (r *LogRecorder) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (r *LogRecorder) Handle(_ context.Context, record slog.Record) error {
+	attrs := make(map[string]interface{}, len(r.attrs)+record.NumAttrs())
+	for _, a := range r.attrs {
+		addLogAttr(attrs, "", a)
+	}
+	prefix := groupPrefix(r.groups)
+	record.Attrs(func(a slog.Attr) bool {
+		addLogAttr(attrs, prefix, a)
+		return true
+	})
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.records = append(*r.records, LogRecord{Level: record.Level, Message: record.Message, Attrs: attrs})
+	return nil
+}
+
+func (r *LogRecorder) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *r
+	prefix := groupPrefix(r.groups)
+	c.attrs = append([]slog.Attr(nil), r.attrs...)
+	for _, a := range attrs {
+		// Store attributes with their group prefix already applied.
+		c.attrs = append(c.attrs, slog.Attr{Key: prefix + a.Key, Value: a.Value})
+	}
+	return &c
+}
+
+func (r *LogRecorder) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return r
+	}
+	c := *r
+	c.groups = append(append([]string(nil), r.groups...), name)
+	return &c
+}
+
+func groupPrefix(groups []string) string {
+	prefix := ""
+	for _, g := range groups {
+		prefix += g + "."
+	}
+	return prefix
+}
+
+func addLogAttr(attrs map[string]interface{}, prefix string, a slog.Attr) {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix += a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			addLogAttr(attrs, groupPrefix, ga)
+		}
+		return
+	}
+	if a.Key == "" {
+		return
+	}
+	attrs[prefix+a.Key] = v.Any()
+}
+
+// HaveLogged succeeds if a LogRecorder (or []LogRecord) has a record with the given level
+// and message, and all of the given attributes.
+// The level, message, and attribute values can be gomega matchers;
+// otherwise they are compared with BeEquivalentTo.
+// Attributes are alternating keys and values, like the arguments to slog.Info,
+// or slog.Attr values. Attributes in groups use keys joined with '.'.
+//
+//	Expect(logs).To(HaveLogged(slog.LevelInfo, "request finished", "request.status", 200))
+//	Expect(logs).To(HaveLogged(BeNumerically(">=", slog.LevelWarn), ContainSubstring("retry")))
+func HaveLogged(level, message interface{}, attrs ...interface{}) gomega.OmegaMatcher {
+	return &matchers.HaveLoggedMatcher{Level: level, Message: message, Attrs: attrs}
+}
@@ -0,0 +1,64 @@
+package golangal_test
+
+import (
+	"log"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("EachLogs", func() {
+	var originalSlog *slog.Logger
+	var originalWriter = log.Writer()
+	BeforeEach(func() {
+		originalSlog = slog.Default()
+		DeferCleanup(func() {
+			Expect(slog.Default()).To(BeIdenticalTo(originalSlog))
+			Expect(log.Writer()).To(BeIdenticalTo(originalWriter))
+		})
+	})
+
+	logs := golangal.EachLogs()
+
+	It("records slog records", func() {
+		slog.Warn("retrying", "attempt", 3)
+		Expect(logs.Records()).To(ConsistOf(golangal.LogRecord{
+			Level:   slog.LevelWarn,
+			Message: "retrying",
+			Attrs:   map[string]interface{}{"attempt": int64(3)},
+		}))
+		Expect(logs).To(golangal.HaveLogged(slog.LevelWarn, "retrying", "attempt", 3))
+	})
+
+	It("records standard log output as info records", func() {
+		log.Printf("hello %s", "world")
+		Expect(logs).To(golangal.HaveLogged(slog.LevelInfo, "hello world"))
+	})
+
+	It("records debug records", func() {
+		slog.Debug("details")
+		Expect(logs).To(golangal.HaveLogged(slog.LevelDebug, "details"))
+	})
+
+	It("resets records for each test", func() {
+		Expect(logs.Records()).To(BeEmpty())
+	})
+
+	It("flattens attributes from loggers and groups", func() {
+		logger := slog.Default().With("service", "api").WithGroup("request")
+		logger.Info("finished", "status", 200, slog.Group("user", "id", "u1"))
+		Expect(logs).To(golangal.HaveLogged(slog.LevelInfo, "finished",
+			"service", "api",
+			"request.status", 200,
+			slog.String("request.user.id", "u1")))
+	})
+
+	It("can be used directly as a handler", func() {
+		recorder := golangal.NewLogRecorder()
+		slog.New(recorder).Error("boom")
+		Expect(recorder).To(golangal.HaveLogged(slog.LevelError, "boom"))
+		Expect(logs.Records()).To(BeEmpty())
+	})
+})
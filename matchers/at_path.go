@@ -0,0 +1,232 @@
+package matchers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+type AtPathMatcher struct {
+	// Path is a dotted path of keys and indices, like "data.items[0].id".
+	// "[*]" matches every element of a slice or map.
+	Path    string
+	Matcher types.GomegaMatcher
+
+	segments []pathSegment
+	notFound string
+	obj      interface{}
+}
+
+type pathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func (m *AtPathMatcher) Match(actual interface{}) (success bool, err error) {
+	m.segments, err = parsePath(m.Path)
+	if err != nil {
+		return false, err
+	}
+	m.notFound = ""
+	var values []interface{}
+	if !m.walk(actual, m.segments, "", &values) {
+		return false, nil
+	}
+	if m.hasWildcard() {
+		m.obj = values
+	} else {
+		m.obj = values[0]
+	}
+	return m.Matcher.Match(m.obj)
+}
+
+func (m *AtPathMatcher) FailureMessage(actual interface{}) (message string) {
+	if m.notFound != "" {
+		return fmt.Sprintf("Path %s not found: %s. Actual:\n%s", m.Path, m.notFound, format.Object(actual, 1))
+	}
+	return fmt.Sprintf("Matcher failed at path %s. %s", m.Path, m.Matcher.FailureMessage(m.obj))
+}
+
+func (m *AtPathMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Matcher matched at path %s. %s", m.Path, m.Matcher.NegatedFailureMessage(m.obj))
+}
+
+func (m *AtPathMatcher) hasWildcard() bool {
+	for _, s := range m.segments {
+		if s.wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// walk resolves segs against v, appending the values found to out.
+// If a segment cannot be resolved, it sets notFound and returns false.
+// at is the path to v, used for failure messages.
+func (m *AtPathMatcher) walk(v interface{}, segs []pathSegment, at string, out *[]interface{}) bool {
+	if len(segs) == 0 {
+		*out = append(*out, v)
+		return true
+	}
+	seg := segs[0]
+	rv := indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		m.notFound = fmt.Sprintf("%s is null", describePath(at))
+		return false
+	}
+	switch {
+	case seg.wildcard:
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				if !m.walk(rv.Index(i).Interface(), segs[1:], fmt.Sprintf("%s[%d]", at, i), out) {
+					return false
+				}
+			}
+			return true
+		case reflect.Map:
+			keys := rv.MapKeys()
+			sort.Slice(keys, func(i, j int) bool {
+				return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+			})
+			for _, k := range keys {
+				if !m.walk(rv.MapIndex(k).Interface(), segs[1:], joinPath(at, fmt.Sprint(k.Interface())), out) {
+					return false
+				}
+			}
+			return true
+		}
+		m.notFound = fmt.Sprintf("%s is %s, not a slice or map", describePath(at), rv.Type())
+		return false
+	case seg.isIndex:
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			m.notFound = fmt.Sprintf("%s is %s, not a slice", describePath(at), rv.Type())
+			return false
+		}
+		if seg.index >= rv.Len() {
+			m.notFound = fmt.Sprintf("%s has length %d, so has no index %d", describePath(at), rv.Len(), seg.index)
+			return false
+		}
+		return m.walk(rv.Index(seg.index).Interface(), segs[1:], fmt.Sprintf("%s[%d]", at, seg.index), out)
+	}
+	var found reflect.Value
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			m.notFound = fmt.Sprintf("%s is %s, which does not have string keys", describePath(at), rv.Type())
+			return false
+		}
+		found = rv.MapIndex(reflect.ValueOf(seg.key).Convert(rv.Type().Key()))
+	case reflect.Struct:
+		var err error
+		if found, err = structField(rv, seg.key); err != nil {
+			m.notFound = fmt.Sprintf("%s field %s is promoted through a nil embedded pointer", describePath(at), seg.key)
+			return false
+		}
+	default:
+		m.notFound = fmt.Sprintf("%s is %s, not a map or struct", describePath(at), rv.Type())
+		return false
+	}
+	if !found.IsValid() {
+		m.notFound = fmt.Sprintf("%s has no key %q", describePath(at), seg.key)
+		return false
+	}
+	return m.walk(found.Interface(), segs[1:], joinPath(at, seg.key), out)
+}
+
+// structField returns the exported field of rv whose json name or Go name is key.
+// It errors if the field is promoted through a nil embedded pointer.
+func structField(rv reflect.Value, key string) (reflect.Value, error) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		if name := strings.Split(f.Tag.Get("json"), ",")[0]; name == key {
+			return rv.Field(i), nil
+		}
+	}
+	if f, ok := t.FieldByName(key); ok && f.PkgPath == "" {
+		return rv.FieldByIndexErr(f.Index)
+	}
+	return reflect.Value{}, nil
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func joinPath(at, key string) string {
+	if at == "" {
+		return key
+	}
+	return at + "." + key
+}
+
+func describePath(at string) string {
+	if at == "" {
+		return "actual"
+	}
+	return at
+}
+
+func parsePath(path string) ([]pathSegment, error) {
+	if path == "" {
+		return nil, errors.New("AtPath requires a non-empty path")
+	}
+	invalid := func(reason string) error {
+		return fmt.Errorf("invalid path %q: %s", path, reason)
+	}
+	var result []pathSegment
+	for i := 0; i < len(path); {
+		if path[i] == '[' {
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, invalid("unclosed '['")
+			}
+			inner := path[i+1 : i+end]
+			if inner == "*" {
+				result = append(result, pathSegment{wildcard: true})
+			} else if idx, err := strconv.Atoi(inner); err == nil && idx >= 0 {
+				result = append(result, pathSegment{index: idx, isIndex: true})
+			} else {
+				return nil, invalid(fmt.Sprintf("index must be a non-negative integer or '*', got %q", inner))
+			}
+			i += end + 1
+		} else {
+			if path[i] == '.' {
+				if i == 0 {
+					return nil, invalid("cannot start with '.'")
+				}
+				i++
+			} else if i > 0 {
+				return nil, invalid(fmt.Sprintf("expected '.' or '[' at offset %d", i))
+			}
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			if end == 0 {
+				return nil, invalid(fmt.Sprintf("empty key at offset %d", i))
+			}
+			result = append(result, pathSegment{key: path[i : i+end]})
+			i += end
+		}
+	}
+	return result, nil
+}
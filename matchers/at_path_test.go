@@ -0,0 +1,109 @@
+package matchers_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("AtPath matcher", func() {
+	var subject interface{}
+	BeforeEach(func() {
+		Expect(json.Unmarshal([]byte(`{
+"data": {"items": [{"id": 1, "tags": ["x"]}, {"id": 2, "tags": []}], "next": null}
+}`), &subject)).To(Succeed())
+	})
+
+	It("matches the value at a path of keys and indices", func() {
+		Expect(subject).To(AtPath("data.items[1].id", BeEquivalentTo(2)))
+		Expect(subject).To(AtPath("data.items[0].tags[0]", "x"))
+		Expect(subject).ToNot(AtPath("data.items[1].id", BeEquivalentTo(1)))
+	})
+
+	It("works with HaveJsonBody", func() {
+		rr := httptest.NewRecorder()
+		rr.WriteString(`{"a": [{"b": "c"}]}`)
+		Expect(rr).To(HaveJsonBody(AtPath("a[0].b", "c")))
+	})
+
+	It("matches wildcards against a slice of the values found", func() {
+		Expect(subject).To(AtPath("data.items[*].id", ConsistOf(BeEquivalentTo(1), BeEquivalentTo(2))))
+		Expect(subject).To(AtPath("data.items[*].tags[*]", Equal([]interface{}{"x"})))
+		Expect(map[string]int{"b": 2, "a": 1}).To(AtPath("[*]", Equal([]interface{}{1, 2})))
+	})
+
+	It("works with Go maps, slices, and structs", func() {
+		type item struct {
+			ID    int `json:"id"`
+			Label string
+		}
+		type resp struct {
+			Items []*item
+			ByKey map[string]item
+		}
+		r := resp{Items: []*item{{ID: 5, Label: "five"}}, ByKey: map[string]item{"k": {ID: 6}}}
+		Expect(r).To(AtPath("Items[0].id", 5))
+		Expect(&r).To(AtPath("Items[0].Label", "five"))
+		Expect(r).To(AtPath("ByKey.k.id", 6))
+		Expect(r).To(AtPath("Items[0].ID", 5))
+		Expect(r).ToNot(AtPath("Items[0].label", "five"))
+	})
+
+	It("fails for fields promoted through a nil embedded pointer", func() {
+		type Inner struct{ X int }
+		type Outer struct{ *Inner }
+		matcher := AtPath("X", 1)
+		Expect(matcher.Match(Outer{})).To(BeFalse())
+		Expect(matcher.FailureMessage(Outer{})).To(HavePrefix("Path X not found: actual field X is promoted through a nil embedded pointer. Actual:\n"))
+		Expect(Outer{Inner: &Inner{X: 1}}).To(AtPath("X", 1))
+	})
+
+	It("fails with the path if the matcher does not match", func() {
+		matcher := AtPath("data.items[0].id", BeEquivalentTo(5))
+		Expect(matcher.Match(subject)).To(BeFalse())
+		Expect(matcher.FailureMessage(subject)).To(HavePrefix(`Matcher failed at path data.items[0].id. Expected
+    <float64>: 1
+to be equivalent to`))
+	})
+
+	It("fails negated with the path", func() {
+		matcher := AtPath("data.items[0].id", BeEquivalentTo(1))
+		Expect(matcher.Match(subject)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(subject)).To(HavePrefix(`Matcher matched at path data.items[0].id. Expected
+    <float64>: 1
+not to be equivalent to`))
+	})
+
+	DescribeTable("fails with the segment that is missing",
+		func(path, reason string) {
+			matcher := AtPath(path, BeNil())
+			success, err := matcher.Match(subject)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(success).To(BeFalse())
+			Expect(matcher.FailureMessage(subject)).To(HavePrefix("Path " + path + " not found: " + reason + ". Actual:\n"))
+		},
+		Entry("missing root key", "meta", `actual has no key "meta"`),
+		Entry("missing nested key", "data.items[0].name", `data.items[0] has no key "name"`),
+		Entry("index out of range", "data.items[2].id", "data.items has length 2, so has no index 2"),
+		Entry("null", "data.next.id", "data.next is null"),
+		Entry("index into an object", "data[0]", "data is map[string]interface {}, not a slice"),
+		Entry("key of a scalar", "data.items[0].id.x", "data.items[0].id is float64, not a map or struct"),
+		Entry("missing inside a wildcard", "data.items[*].tags[0]", "data.items[1].tags has length 0, so has no index 0"),
+	)
+
+	DescribeTable("errors for invalid paths",
+		func(path, msg string) {
+			_, err := AtPath(path, BeNil()).Match(subject)
+			Expect(err).To(MatchError(msg))
+		},
+		Entry("empty", "", "AtPath requires a non-empty path"),
+		Entry("leading dot", ".a", `invalid path ".a": cannot start with '.'`),
+		Entry("trailing dot", "a.", `invalid path "a.": empty key at offset 2`),
+		Entry("unclosed bracket", "a[0", `invalid path "a[0": unclosed '['`),
+		Entry("bad index", "a[-1]", `invalid path "a[-1]": index must be a non-negative integer or '*', got "-1"`),
+		Entry("missing dot", "a[0]b", `invalid path "a[0]b": expected '.' or '[' at offset 4`),
+	)
+})
@@ -0,0 +1,44 @@
+package matchers
+
+import (
+	"fmt"
+	"strings"
+)
+
+type BeCompressedWithMatcher struct {
+	// Encoding is a Content-Encoding coding, like "gzip".
+	Encoding string
+
+	msg       *httpMessage
+	encodings []string
+}
+
+func (m *BeCompressedWithMatcher) Match(actual interface{}) (success bool, err error) {
+	msg, err := requireHttpMessage(actual)
+	if err != nil {
+		return false, err
+	}
+	m.msg = msg
+	m.encodings = contentEncodings(msg.Header)
+	for _, e := range m.encodings {
+		if strings.EqualFold(e, m.Encoding) {
+			return msg.bodyErr == nil, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *BeCompressedWithMatcher) FailureMessage(actual interface{}) (message string) {
+	if len(m.encodings) == 0 {
+		return fmt.Sprintf("Expected Content-Encoding %s, but there was none", m.Encoding)
+	}
+	if m.msg.bodyErr != nil {
+		return fmt.Sprintf("Expected body to be compressed with %s, but %v", m.Encoding, m.msg.bodyErr)
+	}
+	return fmt.Sprintf("Expected Content-Encoding %s, got %s", m.Encoding, strings.Join(m.encodings, ", "))
+}
+
+func (m *BeCompressedWithMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected not to be compressed with %s, but Content-Encoding was %s",
+		m.Encoding, strings.Join(m.encodings, ", "))
+}
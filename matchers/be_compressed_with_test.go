@@ -0,0 +1,126 @@
+package matchers_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+func compress(w io.WriteCloser, s string) {
+	_, err := w.Write([]byte(s))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(w.Close()).To(Succeed())
+}
+
+func gzipped(s string) []byte {
+	buf := &bytes.Buffer{}
+	compress(gzip.NewWriter(buf), s)
+	return buf.Bytes()
+}
+
+var _ = Describe("Content-Encoding decoding", func() {
+	encodedRr := func(encoding string, body []byte) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Type", "application/json")
+		rr.Header().Set("Content-Encoding", encoding)
+		_, _ = rr.Write(body)
+		return rr
+	}
+
+	It("decodes gzip bodies in body matchers", func() {
+		rr := encodedRr("gzip", gzipped(`{"a": 1}`))
+		Expect(rr).To(HaveJsonBody(HaveKeyWithValue("a", BeEquivalentTo(1))))
+		Expect(rr).To(MatchJSONSchema(`{"required": ["a"]}`))
+		Expect(rr).To(HaveResponseCode(200))
+	})
+
+	It("decodes zlib and raw deflate bodies", func() {
+		buf := &bytes.Buffer{}
+		compress(zlib.NewWriter(buf), `{"a": 1}`)
+		Expect(encodedRr("deflate", buf.Bytes())).To(HaveJsonBody(HaveKey("a")))
+
+		buf = &bytes.Buffer{}
+		fw, err := flate.NewWriter(buf, flate.DefaultCompression)
+		Expect(err).ToNot(HaveOccurred())
+		compress(fw, `{"b": 1}`)
+		Expect(encodedRr("deflate", buf.Bytes())).To(HaveJsonBody(HaveKey("b")))
+	})
+
+	It("decodes *http.Response and *http.Request bodies, leaving the raw body", func() {
+		body := gzipped(`{"a": 1}`)
+		resp := &http.Response{
+			Header: http.Header{"Content-Encoding": {"gzip"}},
+			Body:   ioutil.NopCloser(bytes.NewReader(body)),
+		}
+		Expect(resp).To(HaveJsonBody(HaveKey("a")))
+		Expect(ioutil.ReadAll(resp.Body)).To(Equal(body))
+
+		req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+		req.Header.Set("Content-Encoding", "GZIP")
+		Expect(req).To(HaveJsonBody(HaveKey("a")))
+	})
+
+	It("errors for unsupported or corrupt encodings in body matchers only", func() {
+		rr := encodedRr("br", []byte(`xyz`))
+		_, err := HaveJsonBody(BeNil()).Match(rr)
+		Expect(err).To(MatchError("decoding Content-Encoding br: unsupported coding (only gzip and deflate are supported)"))
+		Expect(rr).To(HaveHeader("Content-Encoding", "br"))
+		Expect(rr).To(HaveResponseCode(200))
+
+		_, err = HaveJsonBody(BeNil()).Match(encodedRr("gzip", []byte(`{}`)))
+		Expect(err).To(MatchError("decoding Content-Encoding gzip: unexpected EOF"))
+	})
+})
+
+var _ = Describe("BeCompressedWithMatcher", func() {
+	It("matches the Content-Encoding of a validly encoded body", func() {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Encoding", "gzip")
+		_, _ = rr.Write(gzipped("hi"))
+		Expect(rr).To(BeCompressedWith("gzip"))
+		Expect(rr).To(BeCompressedWith("GZIP"))
+		Expect(rr).ToNot(BeCompressedWith("deflate"))
+	})
+
+	It("fails if there is no Content-Encoding", func() {
+		rr := httptest.NewRecorder()
+		matcher := BeCompressedWith("gzip")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(Equal("Expected Content-Encoding gzip, but there was none"))
+	})
+
+	It("fails if the Content-Encoding is different", func() {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Encoding", "br")
+		matcher := BeCompressedWith("gzip")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(Equal("Expected Content-Encoding gzip, got br"))
+	})
+
+	It("fails if the body is not actually compressed", func() {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Encoding", "gzip")
+		rr.WriteString("plain")
+		matcher := BeCompressedWith("gzip")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(Equal(
+			"Expected body to be compressed with gzip, but decoding Content-Encoding gzip: unexpected EOF"))
+	})
+
+	It("fails negated with the Content-Encoding", func() {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Encoding", "gzip")
+		matcher := BeCompressedWith("gzip")
+		Expect(matcher.Match(rr)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(rr)).To(Equal("Expected not to be compressed with gzip, but Content-Encoding was gzip"))
+	})
+})
@@ -0,0 +1,49 @@
+package matchers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeWithinDurationOfMatcher struct {
+	Expected time.Time
+	Duration time.Duration
+	diff     time.Duration
+}
+
+func (m *BeWithinDurationOfMatcher) Match(actual interface{}) (success bool, err error) {
+	var t time.Time
+	switch a := actual.(type) {
+	case time.Time:
+		t = a
+	case *time.Time:
+		if a == nil {
+			return false, fmt.Errorf("BeWithinDurationOf matcher requires a non-nil *time.Time")
+		}
+		t = *a
+	default:
+		return false, fmt.Errorf("BeWithinDurationOf matcher requires an actual of time.Time. Got:\n%s",
+			format.Object(actual, 1))
+	}
+	m.diff = t.Sub(m.Expected)
+	return m.diff <= m.Duration && m.diff >= -m.Duration, nil
+}
+
+func (m *BeWithinDurationOfMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected\n%s\nto be within %s of\n%s\nbut was %s",
+		format.Object(actual, 1), m.Duration, format.Object(m.Expected, 1), m.describeDiff())
+}
+
+func (m *BeWithinDurationOfMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected\n%s\nnot to be within %s of\n%s\nbut was %s",
+		format.Object(actual, 1), m.Duration, format.Object(m.Expected, 1), m.describeDiff())
+}
+
+func (m *BeWithinDurationOfMatcher) describeDiff() string {
+	if m.diff < 0 {
+		return (-m.diff).String() + " before"
+	}
+	return m.diff.String() + " after"
+}
@@ -0,0 +1,48 @@
+package matchers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("BeWithinDurationOfMatcher", func() {
+	t := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	It("matches times within the duration", func() {
+		Expect(t.Add(time.Second)).To(BeWithinDurationOf(t, time.Second))
+		Expect(t.Add(-time.Second)).To(BeWithinDurationOf(t, time.Second))
+		later := t.Add(2 * time.Second)
+		Expect(&later).ToNot(BeWithinDurationOf(t, time.Second))
+	})
+
+	It("errors for an invalid actual", func() {
+		success, err := BeWithinDurationOf(t, time.Second).Match(5)
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError(HavePrefix("BeWithinDurationOf matcher requires an actual of time.Time")))
+	})
+
+	It("fails with the difference", func() {
+		actual := t.Add(-90 * time.Second)
+		matcher := BeWithinDurationOf(t, time.Minute)
+		success, err := matcher.Match(actual)
+		Expect(success).To(BeFalse())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matcher.FailureMessage(actual)).To(Equal(`Expected
+    <time.Time>: 2019-12-31T23:58:30Z
+to be within 1m0s of
+    <time.Time>: 2020-01-01T00:00:00Z
+but was 1m30s before`))
+	})
+
+	It("fails negated with the difference", func() {
+		matcher := BeWithinDurationOf(t, time.Minute)
+		Expect(matcher.Match(t.Add(time.Second))).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(t.Add(time.Second))).To(HaveSuffix(`
+not to be within 1m0s of
+    <time.Time>: 2020-01-01T00:00:00Z
+but was 1s after`))
+	})
+})
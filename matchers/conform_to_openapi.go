@@ -0,0 +1,295 @@
+package matchers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/rgalanakis/golangal/internal"
+	"gopkg.in/yaml.v3"
+)
+
+type ConformToOpenAPIMatcher struct {
+	// SpecPath is the path to an OpenAPI 3 document in YAML or JSON.
+	SpecPath    string
+	OperationID string
+
+	msg        *httpMessage
+	violations []string
+}
+
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+func (m *ConformToOpenAPIMatcher) Match(actual interface{}) (success bool, err error) {
+	msg, err := requireResponseBody(actual)
+	if err != nil {
+		return false, err
+	}
+	m.msg = msg
+	m.violations = nil
+	spec, err := loadOpenAPISpec(m.SpecPath)
+	if err != nil {
+		return false, err
+	}
+	responses, err := m.operationResponses(spec)
+	if err != nil {
+		return false, err
+	}
+	version, _ := spec["openapi"].(string)
+	validator := &internal.SchemaValidator{Root: spec, OpenAPI30: strings.HasPrefix(version, "3.0")}
+
+	documented, ok := documentedResponse(responses, msg.Code)
+	if !ok {
+		m.violate("status %d is not documented (documented: %s)", msg.Code, strings.Join(sortedKeys(responses), ", "))
+		return false, nil
+	}
+	response, err := resolveOpenAPIRef(spec, documented)
+	if err != nil {
+		return false, err
+	}
+	if err := m.checkHeaders(spec, validator, response); err != nil {
+		return false, err
+	}
+	if err := m.checkContent(validator, response); err != nil {
+		return false, err
+	}
+	return len(m.violations) == 0, nil
+}
+
+func (m *ConformToOpenAPIMatcher) FailureMessage(actual interface{}) (message string) {
+	lines := make([]string, len(m.violations))
+	for i, v := range m.violations {
+		lines[i] = format.Indent + v
+	}
+	return fmt.Sprintf("Expected response to conform to operation %s in %s, but:\n%s\nBody:\n%s",
+		m.OperationID, m.SpecPath, strings.Join(lines, "\n"), m.msg.BodyString())
+}
+
+func (m *ConformToOpenAPIMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected response with status %d not to conform to operation %s in %s",
+		m.msg.Code, m.OperationID, m.SpecPath)
+}
+
+func (m *ConformToOpenAPIMatcher) violate(format string, args ...interface{}) {
+	m.violations = append(m.violations, fmt.Sprintf(format, args...))
+}
+
+func (m *ConformToOpenAPIMatcher) operationResponses(spec map[string]interface{}) (map[string]interface{}, error) {
+	paths, _ := spec["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		item, _ := paths[path].(map[string]interface{})
+		for _, method := range openAPIMethods {
+			op, _ := item[method].(map[string]interface{})
+			if op == nil || op["operationId"] != m.OperationID {
+				continue
+			}
+			responses, ok := op["responses"].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("operation %s in %s has no responses", m.OperationID, m.SpecPath)
+			}
+			return responses, nil
+		}
+	}
+	return nil, fmt.Errorf("operation %s not found in %s", m.OperationID, m.SpecPath)
+}
+
+// documentedResponse returns the response for code,
+// falling back to a range like "2XX", and then to "default".
+func documentedResponse(responses map[string]interface{}, code int) (interface{}, bool) {
+	codeStr := strconv.Itoa(code)
+	for _, key := range []string{codeStr, codeStr[:1] + "XX", codeStr[:1] + "xx", "default"} {
+		if r, ok := responses[key]; ok {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+func (m *ConformToOpenAPIMatcher) checkHeaders(spec map[string]interface{}, validator *internal.SchemaValidator, response map[string]interface{}) error {
+	headers, _ := response["headers"].(map[string]interface{})
+	for _, name := range sortedKeys(headers) {
+		// Content-Type is described by the content, not the headers.
+		if strings.EqualFold(name, "Content-Type") {
+			continue
+		}
+		header, err := resolveOpenAPIRef(spec, headers[name])
+		if err != nil {
+			return err
+		}
+		values := m.msg.Header.Values(name)
+		if len(values) == 0 {
+			if header["required"] == true {
+				m.violate("missing required header %s", name)
+			}
+			continue
+		}
+		schema, ok := header["schema"]
+		if !ok {
+			continue
+		}
+		violations, err := validator.Validate(schema, headerValue(schema, values[0]))
+		if err != nil {
+			return err
+		}
+		for _, v := range violations {
+			m.violate("header %s %s", name, v.Message)
+		}
+	}
+	return nil
+}
+
+// headerValue converts a header value to the JSON type its schema expects, if possible.
+func headerValue(schema interface{}, value string) interface{} {
+	s, _ := schema.(map[string]interface{})
+	switch s["type"] {
+	case "integer", "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+func (m *ConformToOpenAPIMatcher) checkContent(validator *internal.SchemaValidator, response map[string]interface{}) error {
+	content, _ := response["content"].(map[string]interface{})
+	contentType := m.msg.Header.Get("Content-Type")
+	if len(content) == 0 {
+		if len(m.msg.Body) > 0 {
+			m.violate("body is not documented, but response has %d bytes", len(m.msg.Body))
+		}
+		return nil
+	}
+	if contentType == "" {
+		m.violate("missing Content-Type (documented: %s)", strings.Join(sortedKeys(content), ", "))
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		m.violate("invalid Content-Type %q: %v", contentType, err)
+		return nil
+	}
+	media, ok := documentedMediaType(content, mediaType)
+	if !ok {
+		m.violate("Content-Type %s is not documented (documented: %s)", mediaType, strings.Join(sortedKeys(content), ", "))
+		return nil
+	}
+	schema, ok := media["schema"]
+	if !ok || !isJSONMediaType(mediaType) {
+		return nil
+	}
+	var body interface{}
+	if err := json.Unmarshal(m.msg.Body, &body); err != nil {
+		m.violate("%s", strings.Replace(decodeErrorMessage(err, m.msg.Body), "\n", "\n"+format.Indent, -1))
+		return nil
+	}
+	violations, err := validator.Validate(schema, body)
+	if err != nil {
+		return err
+	}
+	for _, v := range violations {
+		m.violate("body %s", v)
+	}
+	return nil
+}
+
+// documentedMediaType returns the media type object for mediaType,
+// falling back to a range like "application/*", and then to "*/*".
+func documentedMediaType(content map[string]interface{}, mediaType string) (map[string]interface{}, bool) {
+	keys := []string{mediaType, strings.SplitN(mediaType, "/", 2)[0] + "/*", "*/*"}
+	for _, key := range keys {
+		for k, v := range content {
+			if strings.EqualFold(k, key) {
+				media, _ := v.(map[string]interface{})
+				return media, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// resolveOpenAPIRef returns the object obj refers to if it is a $ref, or obj itself.
+func resolveOpenAPIRef(spec map[string]interface{}, obj interface{}) (map[string]interface{}, error) {
+	for i := 0; i < 10; i++ {
+		m, _ := obj.(map[string]interface{})
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			return m, nil
+		}
+		if !strings.HasPrefix(ref, "#") {
+			return nil, fmt.Errorf("only $ref within the document is supported, got %s", ref)
+		}
+		target, ok := internal.ResolveJSONPointer(spec, ref[1:])
+		if !ok {
+			return nil, fmt.Errorf("$ref %s not found", ref)
+		}
+		obj = target
+	}
+	return nil, fmt.Errorf("too many nested $ref")
+}
+
+func loadOpenAPISpec(path string) (map[string]interface{}, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+	// YAML keys like 200 are not strings, so convert keys before normalizing into JSON values.
+	normalized, err := internal.NormalizeJSON(stringKeys(doc))
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+	spec, ok := normalized.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not an OpenAPI document", path)
+	}
+	return spec, nil
+}
+
+func stringKeys(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			result[k] = stringKeys(v)
+		}
+		return result
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			result[fmt.Sprint(k)] = stringKeys(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(t))
+		for i, v := range t {
+			result[i] = stringKeys(v)
+		}
+		return result
+	}
+	return v
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
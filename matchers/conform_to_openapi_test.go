@@ -0,0 +1,158 @@
+package matchers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("ConformToOpenAPIMatcher", func() {
+	rootdir := EachFileTree(FileTree{
+		"openapi.yaml": `openapi: 3.0.3
+info: {title: Users, version: "1"}
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      responses:
+        200:
+          description: The user.
+          headers:
+            X-Request-Id:
+              required: true
+              schema: {type: string, minLength: 4}
+            X-Rate-Remaining:
+              schema: {type: integer, minimum: 0}
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/User"}
+        404:
+          $ref: "#/components/responses/NotFound"
+        5XX:
+          description: Server error.
+          content:
+            text/*: {}
+    delete:
+      operationId: deleteUser
+      responses:
+        "204": {description: Deleted.}
+components:
+  schemas:
+    User:
+      type: object
+      required: [id, name]
+      properties:
+        id: {type: integer, minimum: 1, exclusiveMinimum: true}
+        name: {type: string}
+        email: {type: string, nullable: true}
+  responses:
+    NotFound:
+      description: Not found.
+      content:
+        application/problem+json:
+          schema:
+            type: object
+            required: [title]
+`,
+		"openapi.json": `{"openapi": "3.1.0", "paths": {"/": {"get": {"operationId": "root",
+"responses": {"default": {"description": "Any.", "content": {"application/json": {"schema": {"type": ["object", "null"]}}}}}}}}}`,
+	})
+	spec := func() string {
+		return filepath.Join(rootdir(), "openapi.yaml")
+	}
+	newRr := func(code int, contentType, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		if contentType != "" {
+			rr.Header().Set("Content-Type", contentType)
+		}
+		rr.Header().Set("X-Request-Id", "abcd")
+		rr.WriteHeader(code)
+		rr.WriteString(body)
+		return rr
+	}
+
+	It("matches a documented response", func() {
+		Expect(newRr(200, "application/json; charset=utf-8", `{"id": 2, "name": "Rob", "email": null}`)).
+			To(ConformToOpenAPI(spec(), "getUser"))
+		Expect(newRr(404, "application/problem+json", `{"title": "Not Found"}`)).
+			To(ConformToOpenAPI(spec(), "getUser"))
+		Expect(newRr(503, "text/plain", `oops`)).To(ConformToOpenAPI(spec(), "getUser"))
+		Expect(newRr(204, "", "")).To(ConformToOpenAPI(spec(), "deleteUser"))
+	})
+
+	It("loads JSON documents", func() {
+		path := filepath.Join(rootdir(), "openapi.json")
+		Expect(newRr(201, "application/json", `null`)).To(ConformToOpenAPI(path, "root"))
+		Expect(newRr(201, "application/json", `[]`)).ToNot(ConformToOpenAPI(path, "root"))
+	})
+
+	It("can match an *http.Response", func() {
+		resp := newRr(204, "", "").Result()
+		Expect(resp).To(ConformToOpenAPI(spec(), "deleteUser"))
+	})
+
+	It("fails for undocumented status codes", func() {
+		rr := newRr(418, "text/plain", "teapot")
+		matcher := ConformToOpenAPI(spec(), "getUser")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(Equal(`Expected response to conform to operation getUser in ` + spec() + `, but:
+    status 418 is not documented (documented: 200, 404, 5XX)
+Body:
+teapot`))
+	})
+
+	It("lists every header and body violation", func() {
+		rr := newRr(200, "application/json", `{"id": 1, "email": 5}`)
+		rr.Header().Del("X-Request-Id")
+		rr.Header().Set("X-Rate-Remaining", "-1")
+		matcher := ConformToOpenAPI(spec(), "getUser")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(Equal(`Expected response to conform to operation getUser in ` + spec() + `, but:
+    header X-Rate-Remaining -1 is less than minimum 0
+    missing required header X-Request-Id
+    body #: missing required property "name"
+    body #/email: expected type string, got number
+    body #/id: 1 is not more than exclusiveMinimum 1
+Body:
+{"id": 1, "email": 5}`))
+	})
+
+	DescribeTable("fails for content that is not documented",
+		func(code int, contentType, body, operation, violation string) {
+			rr := newRr(code, contentType, body)
+			matcher := ConformToOpenAPI(spec(), operation)
+			Expect(matcher.Match(rr)).To(BeFalse())
+			Expect(matcher.FailureMessage(rr)).To(ContainSubstring("\n    " + violation + "\n"))
+		},
+		Entry("wrong content type", 200, "text/html", "<p>", "getUser",
+			"Content-Type text/html is not documented (documented: application/json)"),
+		Entry("missing content type", 404, "", "{}", "getUser",
+			"missing Content-Type (documented: application/problem+json)"),
+		Entry("undocumented body", 204, "text/plain", "x", "deleteUser",
+			"body is not documented, but response has 1 bytes"),
+		Entry("invalid JSON", 200, "application/json", "{", "getUser",
+			"Error decoding body: unexpected end of JSON input"),
+	)
+
+	It("errors for a missing operation or spec", func() {
+		_, err := ConformToOpenAPI(spec(), "nope").Match(newRr(200, "", ""))
+		Expect(err).To(MatchError("operation nope not found in " + spec()))
+		_, err = ConformToOpenAPI(filepath.Join(rootdir(), "missing.yaml"), "getUser").Match(newRr(200, "", ""))
+		Expect(err).To(HaveOccurred())
+		_, err = ConformToOpenAPI(spec(), "getUser").Match(&http.Request{})
+		Expect(err).To(MatchError("actual must be a *httptest.ResponseRecorder or *http.Response"))
+	})
+
+	It("can be negated", func() {
+		Expect(newRr(418, "", "")).ToNot(ConformToOpenAPI(spec(), "getUser"))
+		rr := newRr(204, "", "")
+		matcher := ConformToOpenAPI(spec(), "deleteUser")
+		Expect(matcher.Match(rr)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(rr)).To(Equal(
+			"Expected response with status 204 not to conform to operation deleteUser in " + spec()))
+	})
+})
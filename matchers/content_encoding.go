@@ -0,0 +1,68 @@
+package matchers
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// contentEncodings returns the lowercase codings in the Content-Encoding header,
+// in the order they were applied, ignoring "identity".
+func contentEncodings(header http.Header) []string {
+	var result []string
+	for _, v := range header.Values("Content-Encoding") {
+		for _, coding := range strings.Split(v, ",") {
+			coding = strings.ToLower(strings.TrimSpace(coding))
+			if coding != "" && coding != "identity" {
+				result = append(result, coding)
+			}
+		}
+	}
+	return result
+}
+
+// decodeContentEncoding decodes body according to the Content-Encoding header.
+// gzip and deflate are supported. Other codings, like br, are an error.
+func decodeContentEncoding(header http.Header, body []byte) ([]byte, error) {
+	codings := contentEncodings(header)
+	if len(body) == 0 {
+		return body, nil
+	}
+	for i := len(codings) - 1; i >= 0; i-- {
+		decoded, err := decodeCoding(codings[i], body)
+		if err != nil {
+			return nil, fmt.Errorf("decoding Content-Encoding %s: %v", codings[i], err)
+		}
+		body = decoded
+	}
+	return body, nil
+}
+
+func decodeCoding(coding string, body []byte) ([]byte, error) {
+	var r io.Reader
+	switch coding {
+	case "gzip", "x-gzip":
+		gr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		r = gr
+	case "deflate":
+		// deflate should be zlib-wrapped, but some servers send raw deflate data.
+		zr, err := zlib.NewReader(bytes.NewReader(body))
+		if err != nil {
+			r = flate.NewReader(bytes.NewReader(body))
+		} else {
+			r = zr
+		}
+	default:
+		return nil, fmt.Errorf("unsupported coding (only gzip and deflate are supported)")
+	}
+	return ioutil.ReadAll(r)
+}
@@ -0,0 +1,53 @@
+package matchers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+)
+
+type HaveCookieMatcher struct {
+	Name   string
+	Inner  types.GomegaMatcher
+	cookie *http.Cookie
+	found  []string
+}
+
+func (matcher *HaveCookieMatcher) Match(actual interface{}) (bool, error) {
+	msg, err := requireHttpMessage(actual)
+	if err != nil {
+		return false, err
+	}
+	var cookies []*http.Cookie
+	if msg.response {
+		cookies = (&http.Response{Header: msg.Header}).Cookies()
+	} else {
+		cookies = (&http.Request{Header: msg.Header}).Cookies()
+	}
+	matcher.cookie = nil
+	matcher.found = make([]string, 0, len(cookies))
+	for _, c := range cookies {
+		matcher.found = append(matcher.found, c.Name)
+		// If a cookie is set more than once, the last one wins.
+		if c.Name == matcher.Name {
+			matcher.cookie = c
+		}
+	}
+	if matcher.cookie == nil {
+		return false, nil
+	}
+	return matcher.Inner.Match(matcher.cookie)
+}
+
+func (matcher *HaveCookieMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.cookie == nil {
+		return fmt.Sprintf("Cookie %s is missing\nFound: %s", matcher.Name, strings.Join(matcher.found, ", "))
+	}
+	return fmt.Sprintf("Cookie %s: %s", matcher.Name, matcher.Inner.FailureMessage(matcher.cookie))
+}
+
+func (matcher *HaveCookieMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Cookie %s: %s", matcher.Name, matcher.Inner.NegatedFailureMessage(matcher.cookie))
+}
@@ -0,0 +1,80 @@
+package matchers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("HaveCookieMatcher", func() {
+	var resp *httptest.ResponseRecorder
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	BeforeEach(func() {
+		resp = httptest.NewRecorder()
+		http.SetCookie(resp, &http.Cookie{Name: "theme", Value: "dark"})
+		http.SetCookie(resp, &http.Cookie{
+			Name:     "session",
+			Value:    "abc",
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+			MaxAge:   3600,
+			Expires:  expires,
+		})
+	})
+
+	It("matches cookie attributes", func() {
+		Expect(resp).To(HaveCookie("session", SatisfyAll(
+			MatchPtrField("Value", "abc"),
+			MatchPtrField("HttpOnly", true),
+			MatchPtrField("Secure", true),
+			MatchPtrField("SameSite", http.SameSiteStrictMode),
+			MatchPtrField("MaxAge", 3600),
+			MatchPtrField("Expires", BeTemporally("==", expires)),
+		)))
+		Expect(resp).ToNot(HaveCookie("theme", MatchPtrField("HttpOnly", true)))
+	})
+
+	It("uses the last cookie with the same name", func() {
+		http.SetCookie(resp, &http.Cookie{Name: "theme", Value: "light"})
+		Expect(resp).To(HaveCookie("theme", MatchPtrField("Value", "light")))
+	})
+
+	It("works with an *http.Response", func() {
+		Expect(resp.Result()).To(HaveCookie("theme", MatchPtrField("Value", "dark")))
+	})
+
+	It("uses the Cookie header of an *http.Request", func() {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.AddCookie(&http.Cookie{Name: "session", Value: "xyz"})
+		Expect(req).To(HaveCookie("session", MatchPtrField("Value", "xyz")))
+	})
+
+	It("errors for an invalid actual", func() {
+		success, err := HaveCookie("x", "y").Match(5)
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError("actual must be a *httptest.ResponseRecorder, *http.Response, or *http.Request"))
+	})
+
+	It("fails if the cookie is missing", func() {
+		matcher := HaveCookie("other", BeNil())
+		Expect(matcher.Match(resp)).To(BeFalse())
+		Expect(matcher.FailureMessage(resp)).To(Equal("Cookie other is missing\nFound: theme, session"))
+	})
+
+	It("fails if the cookie does not match", func() {
+		matcher := HaveCookie("theme", MatchPtrField("Value", "light"))
+		Expect(matcher.Match(resp)).To(BeFalse())
+		Expect(matcher.FailureMessage(resp)).To(HavePrefix("Cookie theme: Field Value of"))
+	})
+
+	It("fails negated if the cookie matches", func() {
+		matcher := HaveCookie("theme", MatchPtrField("Value", "dark"))
+		Expect(matcher.Match(resp)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(resp)).To(HavePrefix("Cookie theme: Field Value of"))
+	})
+})
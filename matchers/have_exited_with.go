@@ -0,0 +1,44 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"github.com/rgalanakis/golangal/internal"
+)
+
+type exitCoder interface {
+	ExitCode() int
+}
+
+type HaveExitedWithMatcher struct {
+	CodeOrMatcher interface{}
+	inner         types.GomegaMatcher
+	code          int
+}
+
+func (m *HaveExitedWithMatcher) Match(actual interface{}) (success bool, err error) {
+	ec, ok := actual.(exitCoder)
+	if !ok {
+		return false, fmt.Errorf("HaveExitedWith matcher requires an actual with an ExitCode() int method. Got:\n%s",
+			format.Object(actual, 1))
+	}
+	m.code = ec.ExitCode()
+	if m.code == -1 {
+		return false, nil
+	}
+	m.inner = internal.CoerceToMatcher(m.CodeOrMatcher)
+	return m.inner.Match(m.code)
+}
+
+func (m *HaveExitedWithMatcher) FailureMessage(actual interface{}) (message string) {
+	if m.code == -1 {
+		return "Expected to have exited, but did not exit"
+	}
+	return "Exit code did not match. " + m.inner.FailureMessage(m.code)
+}
+
+func (m *HaveExitedWithMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return "Exit code matched. " + m.inner.NegatedFailureMessage(m.code)
+}
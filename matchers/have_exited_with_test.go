@@ -0,0 +1,66 @@
+package matchers_test
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+type fakeExiter int
+
+func (f fakeExiter) ExitCode() int {
+	return int(f)
+}
+
+var _ = Describe("HaveExitedWithMatcher", func() {
+	It("can match a code", func() {
+		Expect(fakeExiter(2)).To(HaveExitedWith(2))
+		Expect(fakeExiter(2)).ToNot(HaveExitedWith(1))
+		Expect(fakeExiter(-1)).ToNot(HaveExitedWith(-1))
+	})
+
+	It("can match a matcher", func() {
+		Expect(fakeExiter(2)).To(HaveExitedWith(BeNumerically(">", 0)))
+	})
+
+	It("works with an *os.ProcessState", func() {
+		cmd := exec.Command("sh", "-c", "exit 3")
+		Expect(cmd.Run()).To(HaveOccurred())
+		Expect(cmd.ProcessState).To(HaveExitedWith(3))
+	})
+
+	It("errors for an invalid actual", func() {
+		success, err := HaveExitedWith(1).Match(5)
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError(HavePrefix("HaveExitedWith matcher requires an actual with an ExitCode() int method")))
+	})
+
+	It("fails if the code does not match", func() {
+		matcher := HaveExitedWith(1)
+		success, err := matcher.Match(fakeExiter(2))
+		Expect(success).To(BeFalse())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matcher.FailureMessage(fakeExiter(2))).To(Equal(`Exit code did not match. Expected
+    <int>: 2
+to equal
+    <int>: 1`))
+	})
+
+	It("fails if not exited", func() {
+		matcher := HaveExitedWith(1)
+		success, err := matcher.Match(fakeExiter(-1))
+		Expect(success).To(BeFalse())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matcher.FailureMessage(fakeExiter(-1))).To(Equal("Expected to have exited, but did not exit"))
+	})
+
+	It("fails negated if the code matches", func() {
+		matcher := HaveExitedWith(1)
+		Expect(matcher.Match(fakeExiter(1))).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(fakeExiter(1))).To(HavePrefix(`Exit code matched. Expected
+    <int>: 1
+not to equal`))
+	})
+})
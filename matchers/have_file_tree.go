@@ -0,0 +1,209 @@
+package matchers
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"github.com/rgalanakis/golangal/internal"
+)
+
+// FileTree describes a directory layout.
+// Keys are slash-separated paths relative to the root of the tree.
+// Values can be a string or []byte (the contents of a regular file),
+// a FileEntry (for directories, symlinks, and file modes),
+// or, when matching, a gomega matcher that is matched against the file contents as a string.
+// Parent directories of every path are implied.
+type FileTree map[string]interface{}
+
+// FileEntry describes a single path in a FileTree.
+type FileEntry struct {
+	// Content is the contents of a regular file.
+	// It can be a string, []byte, or (when matching) a gomega matcher.
+	Content interface{}
+	// Mode is the permission bits of the file or directory.
+	// If zero, files are written with 0644 and directories with 0755,
+	// and the mode is not checked when matching.
+	Mode os.FileMode
+	// Dir is true if the entry is a directory.
+	Dir bool
+	// Symlink is the target of a symbolic link.
+	Symlink string
+}
+
+type HaveFileTreeMatcher struct {
+	Tree FileTree
+
+	missing []string
+	extra   []string
+	changed []string
+}
+
+func (m *HaveFileTreeMatcher) Match(actual interface{}) (success bool, err error) {
+	root, ok := actual.(string)
+	if !ok {
+		return false, errors.New("HaveFileTree matcher requires an actual of string directory path")
+	}
+	if info, err := os.Stat(root); err != nil {
+		return false, err
+	} else if !info.IsDir() {
+		return false, fmt.Errorf("%s is not a directory", root)
+	}
+
+	m.missing, m.extra, m.changed = nil, nil, nil
+	implied := make(map[string]bool, len(m.Tree))
+	for p := range m.Tree {
+		for dir := filepath.Dir(filepath.FromSlash(p)); dir != "."; dir = filepath.Dir(dir) {
+			implied[filepath.ToSlash(dir)] = true
+		}
+	}
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if expected, ok := m.Tree[rel]; ok {
+			if diff, err := compareFileEntry(path, info, ToFileEntry(expected)); err != nil {
+				return err
+			} else if diff != "" {
+				m.changed = append(m.changed, rel+": "+diff)
+			}
+			return nil
+		}
+		if implied[rel] {
+			if !info.IsDir() {
+				m.changed = append(m.changed, rel+": expected directory, got "+describeMode(info.Mode()))
+			}
+			return nil
+		}
+		if info.IsDir() {
+			m.extra = append(m.extra, rel+"/")
+			return filepath.SkipDir
+		}
+		m.extra = append(m.extra, rel)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	for p := range m.Tree {
+		if _, err := os.Lstat(filepath.Join(root, filepath.FromSlash(p))); os.IsNotExist(err) {
+			m.missing = append(m.missing, p)
+		}
+	}
+	sort.Strings(m.missing)
+	sort.Strings(m.extra)
+	sort.Strings(m.changed)
+	return len(m.missing) == 0 && len(m.extra) == 0 && len(m.changed) == 0, nil
+}
+
+func (m *HaveFileTreeMatcher) FailureMessage(actual interface{}) (message string) {
+	bld := &strings.Builder{}
+	bld.WriteString("Expected directory\n")
+	bld.WriteString(format.Object(actual, 1))
+	bld.WriteString("\nto match file tree, but found differences:")
+	writeFileTreeDiff(bld, "missing", m.missing)
+	writeFileTreeDiff(bld, "extra", m.extra)
+	writeFileTreeDiff(bld, "changed", m.changed)
+	return bld.String()
+}
+
+func (m *HaveFileTreeMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected directory\n%s\nnot to match file tree", format.Object(actual, 1))
+}
+
+func writeFileTreeDiff(bld *strings.Builder, label string, paths []string) {
+	for _, p := range paths {
+		bld.WriteString("\n")
+		bld.WriteString(format.Indent)
+		bld.WriteString(label)
+		bld.WriteString(": ")
+		bld.WriteString(p)
+	}
+}
+
+// ToFileEntry converts a FileTree value into a FileEntry.
+func ToFileEntry(v interface{}) FileEntry {
+	switch t := v.(type) {
+	case FileEntry:
+		return t
+	case *FileEntry:
+		return *t
+	default:
+		return FileEntry{Content: v}
+	}
+}
+
+func compareFileEntry(path string, info os.FileInfo, expected FileEntry) (string, error) {
+	mode := info.Mode()
+	switch {
+	case expected.Symlink != "":
+		if mode&os.ModeSymlink == 0 {
+			return "expected symlink, got " + describeMode(mode), nil
+		}
+		target, err := os.Readlink(path)
+		if err != nil {
+			return "", err
+		}
+		if target != expected.Symlink {
+			return fmt.Sprintf("expected symlink to %q, got symlink to %q", expected.Symlink, target), nil
+		}
+		return "", nil
+	case expected.Dir:
+		if !mode.IsDir() {
+			return "expected directory, got " + describeMode(mode), nil
+		}
+	default:
+		if !mode.IsRegular() {
+			return "expected file, got " + describeMode(mode), nil
+		}
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		matcher := fileContentMatcher(expected.Content)
+		if ok, err := matcher.Match(string(contents)); err != nil {
+			return "", err
+		} else if !ok {
+			return "content mismatch\n" + format.IndentString(matcher.FailureMessage(string(contents)), 2), nil
+		}
+	}
+	if expected.Mode != 0 && mode.Perm() != expected.Mode.Perm() {
+		return fmt.Sprintf("expected mode %s, got %s", expected.Mode.Perm(), mode.Perm()), nil
+	}
+	return "", nil
+}
+
+func fileContentMatcher(content interface{}) types.GomegaMatcher {
+	switch t := content.(type) {
+	case nil:
+		return internal.CoerceToMatcher("")
+	case []byte:
+		return internal.CoerceToMatcher(string(t))
+	default:
+		return internal.CoerceToMatcher(t)
+	}
+}
+
+func describeMode(mode os.FileMode) string {
+	switch {
+	case mode&os.ModeSymlink != 0:
+		return "symlink"
+	case mode.IsDir():
+		return "directory"
+	case mode.IsRegular():
+		return "file"
+	default:
+		return mode.String()
+	}
+}
@@ -0,0 +1,93 @@
+package matchers_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+	"github.com/rgalanakis/golangal/matchers"
+)
+
+var _ = Describe("HaveFileTreeMatcher", func() {
+	rootdir := EachFileTree(FileTree{
+		"a.txt":       "hello",
+		"sub/b.txt":   []byte("world"),
+		"sub/run.sh":  File("#!/bin/sh", 0755),
+		"empty":       Dir(0700),
+		"link-to-sub": Symlink("sub"),
+	})
+
+	It("matches an identical tree", func() {
+		Expect(rootdir()).To(HaveFileTree(FileTree{
+			"a.txt":       "hello",
+			"sub/b.txt":   ContainSubstring("orl"),
+			"sub/run.sh":  File("#!/bin/sh", 0755),
+			"empty":       Dir(0),
+			"link-to-sub": Symlink("sub"),
+		}))
+	})
+
+	It("errors for an invalid actual", func() {
+		success, err := (&matchers.HaveFileTreeMatcher{}).Match(5)
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError("HaveFileTree matcher requires an actual of string directory path"))
+	})
+
+	It("errors if the actual is not a directory", func() {
+		success, err := (&matchers.HaveFileTreeMatcher{}).Match(filepath.Join(rootdir(), "a.txt"))
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError(HaveSuffix("a.txt is not a directory")))
+	})
+
+	It("fails with every missing, extra, and changed path", func() {
+		Expect(os.WriteFile(filepath.Join(rootdir(), "empty", "extra.txt"), []byte("x"), 0644)).To(Succeed())
+		matcher := HaveFileTree(FileTree{
+			"a.txt":       "goodbye",
+			"sub/b.txt":   "world",
+			"sub/run.sh":  File("#!/bin/sh", 0700),
+			"sub/c.txt":   "new",
+			"link-to-sub": Symlink("a.txt"),
+		})
+		success, err := matcher.Match(rootdir())
+		Expect(success).To(BeFalse())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matcher.FailureMessage(rootdir())).To(HaveSuffix(`
+to match file tree, but found differences:
+    missing: sub/c.txt
+    extra: empty/
+    changed: a.txt: content mismatch
+        Expected
+            <string>: hello
+        to equal
+            <string>: goodbye
+    changed: link-to-sub: expected symlink to "a.txt", got symlink to "sub"
+    changed: sub/run.sh: expected mode -rwx------, got -rwxr-xr-x`))
+	})
+
+	It("fails if a path has the wrong type", func() {
+		matcher := HaveFileTree(FileTree{
+			"a.txt":       Dir(0),
+			"sub/b.txt":   Symlink("x"),
+			"sub/run.sh":  "#!/bin/sh",
+			"empty":       "",
+			"link-to-sub": "",
+		})
+		success, err := matcher.Match(rootdir())
+		Expect(success).To(BeFalse())
+		Expect(err).ToNot(HaveOccurred())
+		msg := matcher.FailureMessage(rootdir())
+		Expect(msg).To(ContainSubstring("changed: a.txt: expected directory, got file"))
+		Expect(msg).To(ContainSubstring("changed: empty: expected file, got directory"))
+		Expect(msg).To(ContainSubstring("changed: link-to-sub: expected file, got symlink"))
+		Expect(msg).To(ContainSubstring("changed: sub/b.txt: expected symlink, got file"))
+	})
+
+	It("can be negated", func() {
+		Expect(rootdir()).ToNot(HaveFileTree(FileTree{"a.txt": "hello"}))
+		Expect(HaveFileTree(FileTree{}).NegatedFailureMessage("/x")).To(Equal(`Expected directory
+    <string>: /x
+not to match file tree`))
+	})
+})
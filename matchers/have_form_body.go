@@ -0,0 +1,41 @@
+package matchers
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/onsi/gomega/types"
+)
+
+type HaveFormBodyMatcher struct {
+	Inner types.GomegaMatcher
+
+	values url.Values
+}
+
+// Match errors if the Content-Type is not a form or the body cannot be parsed,
+// like HaveJsonBodyMatcher.
+func (matcher *HaveFormBodyMatcher) Match(actual interface{}) (bool, error) {
+	msg, err := requireHttpBody(actual)
+	if err != nil {
+		return false, err
+	}
+	problem := checkContentType(msg, "application/x-www-form-urlencoded", func(mediaType string) bool {
+		return mediaType == "application/x-www-form-urlencoded"
+	})
+	if problem != "" {
+		return false, errors.New(problem)
+	}
+	if matcher.values, err = url.ParseQuery(string(msg.Body)); err != nil {
+		return false, errors.New(decodeErrorMessageAt(err, msg.Body, -1))
+	}
+	return matcher.Inner.Match(matcher.values)
+}
+
+func (matcher *HaveFormBodyMatcher) FailureMessage(actual interface{}) (message string) {
+	return matcher.Inner.FailureMessage(matcher.values)
+}
+
+func (matcher *HaveFormBodyMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return matcher.Inner.NegatedFailureMessage(matcher.values)
+}
@@ -0,0 +1,52 @@
+package matchers_test
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("HaveFormBodyMatcher", func() {
+	newReq := func(contentType, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Type", contentType)
+		rr.WriteString(body)
+		return rr
+	}
+
+	It("matches the decoded values", func() {
+		rr := newReq("application/x-www-form-urlencoded", "a=1&b=2&b=3")
+		Expect(rr).To(HaveFormBody(Equal(url.Values{"a": {"1"}, "b": {"2", "3"}})))
+		Expect(rr).To(HaveFormBody(HaveKeyWithValue("a", []string{"1"})))
+		Expect(rr).ToNot(HaveFormBody(HaveKey("c")))
+	})
+
+	It("can match a request", func() {
+		req := httptest.NewRequest("POST", "/", strings.NewReader("name=Rob"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+		Expect(req).To(HaveFormBody(HaveKeyWithValue("name", ConsistOf("Rob"))))
+	})
+
+	It("errors for the wrong content type, even when negated", func() {
+		rr := newReq("application/json", `{"debug": true}`)
+		_, err := HaveFormBody(HaveKey("debug")).Match(rr)
+		Expect(err).To(MatchError("Expected Content-Type application/x-www-form-urlencoded, got application/json"))
+	})
+
+	It("errors with the body if it cannot be decoded", func() {
+		rr := newReq("application/x-www-form-urlencoded", "a=%zz")
+		_, err := HaveFormBody(HaveKey("a")).Match(rr)
+		Expect(err).To(MatchError("Error decoding body: invalid URL escape \"%zz\"\nBody:\na=%zz"))
+	})
+
+	It("fails negated with the inner message", func() {
+		rr := newReq("application/x-www-form-urlencoded", "a=1")
+		matcher := HaveFormBody(HaveKey("a"))
+		Expect(matcher.Match(rr)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(rr)).To(ContainSubstring("not to have key"))
+	})
+})
@@ -1,8 +1,9 @@
 package matchers
 
 import (
+	"fmt"
+	"github.com/onsi/gomega/format"
 	"github.com/onsi/gomega/types"
-	"net/http/httptest"
 	"sort"
 	"strings"
 )
@@ -10,27 +11,46 @@ import (
 type HaveHeaderMatcher struct {
 	Key   string
 	Inner types.GomegaMatcher
-	rr    *httptest.ResponseRecorder
-	got   string
+	// Absent inverts the matcher to succeed only if the header is not present at all.
+	// A header that is present with an empty value is not absent.
+	// Inner is ignored.
+	Absent  bool
+	msg     *httpMessage
+	got     string
+	present bool
 }
 
 func (matcher *HaveHeaderMatcher) Match(actual interface{}) (bool, error) {
-	rr, err := requireRespRec(actual)
+	msg, err := requireHttpMessage(actual)
 	if err != nil {
 		return false, err
 	}
-	matcher.rr = rr
-	matcher.got = rr.Header().Get(matcher.Key)
+	matcher.msg = msg
+	matcher.got = msg.Header.Get(matcher.Key)
+	matcher.present = len(msg.Header.Values(matcher.Key)) > 0
+	if matcher.Absent {
+		return !matcher.present, nil
+	}
+	if !matcher.present {
+		return false, nil
+	}
 	return matcher.Inner.Match(matcher.got)
 }
 
 func (matcher *HaveHeaderMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.Absent {
+		if matcher.got == "" {
+			return fmt.Sprintf("Expected %s to be absent, but it is present with an empty value", matcher.Key)
+		}
+		return fmt.Sprintf("Expected %s to be absent, but it is present with value\n%s",
+			matcher.Key, format.Object(matcher.got, 1))
+	}
 	bld := &strings.Builder{}
-	if matcher.got == "" {
+	if !matcher.present {
 		bld.WriteString(matcher.Key)
 		bld.WriteString(" is missing\nFound: ")
-		headers := make([]string, 0, len(matcher.rr.Header()))
-		for k := range matcher.rr.Header() {
+		headers := make([]string, 0, len(matcher.msg.Header))
+		for k := range matcher.msg.Header {
 			headers = append(headers, k)
 		}
 		sort.Strings(headers)
@@ -44,5 +64,8 @@ func (matcher *HaveHeaderMatcher) FailureMessage(actual interface{}) (message st
 }
 
 func (matcher *HaveHeaderMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	panic(noNegate)
+	if matcher.Absent {
+		return fmt.Sprintf("Expected %s to be present, but it is missing", matcher.Key)
+	}
+	return matcher.Key + ": " + matcher.Inner.NegatedFailureMessage(matcher.got)
 }
@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/rgalanakis/golangal"
 	"github.com/rgalanakis/golangal/matchers"
+	"net/http"
 	"net/http/httptest"
 )
 
@@ -21,10 +22,17 @@ var _ = Describe("HaveHeaderMatcher", func() {
 		resp.Header().Add("Test-Header", "somestring")
 		Expect(resp).ToNot(HaveHeader("Test-Header", ContainSubstring("otherstring")))
 	})
+	It("can match an *http.Response and *http.Request", func() {
+		resp := &http.Response{Header: http.Header{"Test-Header": {"somestring"}}}
+		Expect(resp).To(HaveHeader("Test-Header", "somestring"))
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer xyz")
+		Expect(req).To(HaveHeader("Authorization", HavePrefix("Bearer ")))
+	})
 	It("errors for an invalid actual", func() {
 		success, err = (&matchers.HaveHeaderMatcher{}).Match(5)
 		Expect(success).To(BeFalse())
-		Expect(err).To(MatchError("actual must be a *httptest.ResponseRecorder"))
+		Expect(err).To(MatchError("actual must be a *httptest.ResponseRecorder, *http.Response, or *http.Request"))
 	})
 	It("fails if inner matcher does not match", func() {
 		resp := &httptest.ResponseRecorder{}
@@ -50,3 +58,58 @@ to contain substring
 Found: Another-Header, Test-Header`))
 	})
 })
+
+var _ = Describe("HaveHeaderMatcher negation", func() {
+	var resp *httptest.ResponseRecorder
+	BeforeEach(func() {
+		resp = httptest.NewRecorder()
+		resp.Header().Set("Test-Header", "somestring")
+		resp.Header()["Empty-Header"] = []string{""}
+	})
+
+	It("succeeds negated if the header is missing or does not match", func() {
+		Expect(resp).ToNot(HaveHeader("Other-Header", "x"))
+		Expect(resp).ToNot(HaveHeader("Test-Header", "x"))
+	})
+
+	It("does not match a missing header against an empty value", func() {
+		Expect(resp).ToNot(HaveHeader("Other-Header", ""))
+		Expect(resp).To(HaveHeader("Empty-Header", ""))
+	})
+
+	It("fails negated if the header matches", func() {
+		matcher := HaveHeader("Test-Header", ContainSubstring("some"))
+		Expect(matcher.Match(resp)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(resp)).To(HavePrefix(`Test-Header: Expected
+    <string>: somestring
+not to contain substring
+    <string>: some`))
+	})
+
+	Describe("HaveNoHeader", func() {
+		It("matches if the header is missing", func() {
+			Expect(resp).To(HaveNoHeader("Other-Header"))
+			Expect(resp).ToNot(HaveNoHeader("Test-Header"))
+		})
+
+		It("fails if the header is present", func() {
+			matcher := HaveNoHeader("Test-Header")
+			Expect(matcher.Match(resp)).To(BeFalse())
+			Expect(matcher.FailureMessage(resp)).To(Equal(`Expected Test-Header to be absent, but it is present with value
+    <string>: somestring`))
+		})
+
+		It("fails if the header is present but empty", func() {
+			matcher := HaveNoHeader("Empty-Header")
+			Expect(matcher.Match(resp)).To(BeFalse())
+			Expect(matcher.FailureMessage(resp)).To(Equal(
+				`Expected Empty-Header to be absent, but it is present with an empty value`))
+		})
+
+		It("fails negated if the header is missing", func() {
+			matcher := HaveNoHeader("Other-Header")
+			Expect(matcher.Match(resp)).To(BeTrue())
+			Expect(matcher.NegatedFailureMessage(resp)).To(Equal(`Expected Other-Header to be present, but it is missing`))
+		})
+	})
+})
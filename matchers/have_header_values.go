@@ -0,0 +1,31 @@
+package matchers
+
+import (
+	"github.com/onsi/gomega/types"
+)
+
+type HaveHeaderValuesMatcher struct {
+	Key    string
+	Inner  types.GomegaMatcher
+	values []string
+}
+
+func (matcher *HaveHeaderValuesMatcher) Match(actual interface{}) (bool, error) {
+	msg, err := requireHttpMessage(actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.values = msg.Header.Values(matcher.Key)
+	if matcher.values == nil {
+		matcher.values = []string{}
+	}
+	return matcher.Inner.Match(matcher.values)
+}
+
+func (matcher *HaveHeaderValuesMatcher) FailureMessage(actual interface{}) (message string) {
+	return matcher.Key + ": " + matcher.Inner.FailureMessage(matcher.values)
+}
+
+func (matcher *HaveHeaderValuesMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return matcher.Key + ": " + matcher.Inner.NegatedFailureMessage(matcher.values)
+}
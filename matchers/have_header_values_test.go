@@ -0,0 +1,53 @@
+package matchers_test
+
+import (
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+	"github.com/rgalanakis/golangal/matchers"
+)
+
+var _ = Describe("HaveHeaderValuesMatcher", func() {
+	var resp *httptest.ResponseRecorder
+	BeforeEach(func() {
+		resp = httptest.NewRecorder()
+		resp.Header().Add("Set-Cookie", "a=1")
+		resp.Header().Add("Set-Cookie", "b=2")
+	})
+
+	It("matches every value of the header", func() {
+		Expect(resp).To(HaveHeaderValues("Set-Cookie", ConsistOf("b=2", "a=1")))
+		Expect(resp).To(HaveHeaderValues("Set-Cookie", ContainElement("b=2")))
+		Expect(resp).To(HaveHeaderValues("Set-Cookie", []string{"a=1", "b=2"}))
+		Expect(resp).ToNot(HaveHeaderValues("Set-Cookie", ContainElement("c=3")))
+	})
+
+	It("matches missing headers as an empty slice", func() {
+		Expect(resp).To(HaveHeaderValues("Vary", BeEmpty()))
+		Expect(resp).To(HaveHeaderValues("Vary", []string{}))
+	})
+
+	It("errors for an invalid actual", func() {
+		success, err := (&matchers.HaveHeaderValuesMatcher{}).Match(5)
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError("actual must be a *httptest.ResponseRecorder, *http.Response, or *http.Request"))
+	})
+
+	It("fails with the header values", func() {
+		matcher := HaveHeaderValues("Set-Cookie", HaveLen(1))
+		Expect(matcher.Match(resp)).To(BeFalse())
+		Expect(matcher.FailureMessage(resp)).To(Equal(`Set-Cookie: Expected
+    <[]string | len:2, cap:2>: ["a=1", "b=2"]
+to have length 1`))
+	})
+
+	It("fails negated with the header values", func() {
+		matcher := HaveHeaderValues("Set-Cookie", HaveLen(2))
+		Expect(matcher.Match(resp)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(resp)).To(HavePrefix(`Set-Cookie: Expected
+    <[]string | len:2, cap:2>: ["a=1", "b=2"]
+not to have length 2`))
+	})
+})
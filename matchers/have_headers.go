@@ -0,0 +1,49 @@
+package matchers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+)
+
+type HaveHeadersMatcher struct {
+	Headers  map[string]types.GomegaMatcher
+	failures []string
+}
+
+func (matcher *HaveHeadersMatcher) Match(actual interface{}) (bool, error) {
+	if _, err := requireHttpMessage(actual); err != nil {
+		return false, err
+	}
+	keys := make([]string, 0, len(matcher.Headers))
+	for k := range matcher.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	matcher.failures = nil
+	for _, k := range keys {
+		hm := &HaveHeaderMatcher{Key: k, Inner: matcher.Headers[k]}
+		success, err := hm.Match(actual)
+		if err != nil {
+			return false, err
+		}
+		if !success {
+			matcher.failures = append(matcher.failures, hm.FailureMessage(actual))
+		}
+	}
+	return len(matcher.failures) == 0, nil
+}
+
+func (matcher *HaveHeadersMatcher) FailureMessage(actual interface{}) (message string) {
+	return "Headers did not match:\n" + strings.Join(matcher.failures, "\n")
+}
+
+func (matcher *HaveHeadersMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	keys := make([]string, 0, len(matcher.Headers))
+	for k := range matcher.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return "Expected headers not to match, but all matched: " + strings.Join(keys, ", ")
+}
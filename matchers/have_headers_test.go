@@ -0,0 +1,58 @@
+package matchers_test
+
+import (
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("HaveHeadersMatcher", func() {
+	var resp *httptest.ResponseRecorder
+	BeforeEach(func() {
+		resp = httptest.NewRecorder()
+		resp.Header().Set("Content-Type", "application/json")
+		resp.Header().Set("Cache-Control", "no-store")
+	})
+
+	It("matches every header", func() {
+		Expect(resp).To(HaveHeaders(map[string]interface{}{
+			"Content-Type":  "application/json",
+			"Cache-Control": ContainSubstring("no-store"),
+		}))
+		Expect(resp).ToNot(HaveHeaders(map[string]interface{}{
+			"Content-Type":  "application/json",
+			"Cache-Control": "public",
+		}))
+	})
+
+	It("errors for an invalid actual", func() {
+		success, err := HaveHeaders(nil).Match(5)
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError("actual must be a *httptest.ResponseRecorder, *http.Response, or *http.Request"))
+	})
+
+	It("fails with every mismatched header", func() {
+		matcher := HaveHeaders(map[string]interface{}{
+			"Content-Type":    "text/html",
+			"Cache-Control":   "no-store",
+			"X-Frame-Options": "DENY",
+		})
+		Expect(matcher.Match(resp)).To(BeFalse())
+		Expect(matcher.FailureMessage(resp)).To(Equal(`Headers did not match:
+Content-Type: Expected
+    <string>: application/json
+to equal
+    <string>: text/html
+X-Frame-Options is missing
+Found: Cache-Control, Content-Type`))
+	})
+
+	It("fails negated if every header matches", func() {
+		matcher := HaveHeaders(map[string]interface{}{"Content-Type": "application/json", "Cache-Control": "no-store"})
+		Expect(matcher.Match(resp)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(resp)).To(Equal(
+			"Expected headers not to match, but all matched: Cache-Control, Content-Type"))
+	})
+})
@@ -2,38 +2,33 @@ package matchers
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
+
 	"github.com/onsi/gomega/types"
-	"net/http/httptest"
 )
 
 type HaveJsonBodyMatcher struct {
 	Inner          types.GomegaMatcher
-	rr             *httptest.ResponseRecorder
 	actualBodyJson interface{}
-	decodeErr      error
 }
 
+// Match errors if the body is not valid JSON, rather than failing,
+// so a negated match cannot pass because of an unexpected body, like an HTML error page.
 func (matcher *HaveJsonBodyMatcher) Match(actual interface{}) (bool, error) {
-	rr, err := requireRespRec(actual)
+	msg, err := requireHttpBody(actual)
 	if err != nil {
 		return false, err
 	}
-	matcher.rr = rr
-	if err := json.Unmarshal(rr.Body.Bytes(), &matcher.actualBodyJson); err != nil {
-		matcher.decodeErr = err
-		return false, nil
+	if err := json.Unmarshal(msg.Body, &matcher.actualBodyJson); err != nil {
+		return false, errors.New(decodeErrorMessage(err, msg.Body))
 	}
 	return matcher.Inner.Match(matcher.actualBodyJson)
 }
 
 func (matcher *HaveJsonBodyMatcher) FailureMessage(actual interface{}) (message string) {
-	if matcher.decodeErr != nil {
-		return fmt.Sprintf("Error decoding body: %+v", matcher.decodeErr)
-	}
 	return matcher.Inner.FailureMessage(matcher.actualBodyJson)
 }
 
 func (matcher *HaveJsonBodyMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	panic(noNegate)
+	return matcher.Inner.NegatedFailureMessage(matcher.actualBodyJson)
 }
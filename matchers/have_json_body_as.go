@@ -0,0 +1,59 @@
+package matchers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/types"
+)
+
+type HaveJsonBodyAsMatcher struct {
+	// Target is a non-nil pointer the body is decoded into.
+	// It is passed to Inner after decoding.
+	Target interface{}
+	Inner  types.GomegaMatcher
+	// DisallowUnknownFields causes decoding to fail if the body has object keys
+	// that do not match a field in Target.
+	DisallowUnknownFields bool
+}
+
+// Match errors if the body cannot be decoded into Target, rather than failing,
+// like HaveJsonBodyMatcher.
+func (matcher *HaveJsonBodyAsMatcher) Match(actual interface{}) (bool, error) {
+	msg, err := requireHttpBody(actual)
+	if err != nil {
+		return false, err
+	}
+	target := reflect.ValueOf(matcher.Target)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return false, fmt.Errorf("HaveJsonBodyAs requires a non-nil pointer target, got %T", matcher.Target)
+	}
+	// Reset the target, so it does not keep values from a previous match.
+	target.Elem().Set(reflect.Zero(target.Elem().Type()))
+	dec := json.NewDecoder(bytes.NewReader(msg.Body))
+	if matcher.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(matcher.Target); err != nil {
+		return false, matcher.decodeError(err, msg)
+	}
+	if dec.More() {
+		return false, matcher.decodeError(errors.New("unexpected data after top-level JSON value"), msg)
+	}
+	return matcher.Inner.Match(matcher.Target)
+}
+
+func (matcher *HaveJsonBodyAsMatcher) decodeError(err error, msg *httpMessage) error {
+	return fmt.Errorf("Decoding into %T. %s", matcher.Target, decodeErrorMessage(err, msg.Body))
+}
+
+func (matcher *HaveJsonBodyAsMatcher) FailureMessage(actual interface{}) (message string) {
+	return matcher.Inner.FailureMessage(matcher.Target)
+}
+
+func (matcher *HaveJsonBodyAsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return matcher.Inner.NegatedFailureMessage(matcher.Target)
+}
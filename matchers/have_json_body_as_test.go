@@ -0,0 +1,90 @@
+package matchers_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+	"github.com/rgalanakis/golangal/matchers"
+)
+
+var _ = Describe("HaveJsonBodyAsMatcher", func() {
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	newRr := func(body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		rr.WriteString(body)
+		return rr
+	}
+
+	It("decodes into the target and matches it", func() {
+		var u user
+		Expect(newRr(`{"id": 5, "name": "Rob"}`)).To(HaveJsonBodyAs(&u, MatchPtrField("ID", 5)))
+		Expect(u).To(Equal(user{ID: 5, Name: "Rob"}))
+	})
+	It("can match an *http.Response", func() {
+		resp := &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(`[1, 2]`))}
+		var ints []int
+		Expect(resp).To(HaveJsonBodyAs(&ints, Not(BeNil())))
+		Expect(ints).To(Equal([]int{1, 2}))
+		Expect(ioutil.ReadAll(resp.Body)).To(MatchJSON(`[1, 2]`))
+	})
+	It("resets the target before decoding", func() {
+		u := user{ID: 1, Name: "Old"}
+		Expect(newRr(`{"id": 5}`)).To(HaveJsonBodyAs(&u, Not(BeNil())))
+		Expect(u).To(Equal(user{ID: 5}))
+	})
+	It("ignores unknown fields unless strict", func() {
+		rr := newRr(`{"id": 5, "email": "x@y.z"}`)
+		Expect(rr).To(HaveJsonBodyAs(&user{}, Not(BeNil())))
+
+		_, err := HaveStrictJsonBodyAs(&user{}, Not(BeNil())).Match(rr)
+		Expect(err).To(MatchError(`Decoding into *matchers_test.user. Error decoding body: json: unknown field "email"
+Body:
+{"id": 5, "email": "x@y.z"}`))
+	})
+	It("errors with the body snippet for type errors", func() {
+		_, err := HaveJsonBodyAs(&user{}, Not(BeNil())).Match(newRr(`{"id": "five"}`))
+		Expect(err).To(MatchError(`Decoding into *matchers_test.user. Error decoding body: json: cannot unmarshal string into Go struct field user.id of type int
+Body near offset 13:
+{"id": "five"}`))
+	})
+	It("truncates long bodies around the error", func() {
+		body := `{"x": "` + strings.Repeat("a", 300) + `", "id": "five", "y": "` + strings.Repeat("b", 300) + `"}`
+		_, err := HaveJsonBodyAs(&user{}, Not(BeNil())).Match(newRr(body))
+		Expect(err).To(HaveOccurred())
+		msg := err.Error()
+		Expect(msg).To(ContainSubstring(`aaa", "id": "five", "y": "bbb`))
+		Expect(msg).To(ContainSubstring("\n...aaa"))
+		Expect(msg).To(HaveSuffix(`bbb...`))
+	})
+	It("errors if there is data after the JSON value", func() {
+		_, err := HaveJsonBodyAs(&user{}, Not(BeNil())).Match(newRr(`{"id": 5} {"id": 6}`))
+		Expect(err).To(MatchError(ContainSubstring("unexpected data after top-level JSON value")))
+	})
+	It("errors negated if the body is not JSON", func() {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Type", "text/html")
+		rr.WriteString("<html>Internal Server Error</html>")
+		_, err := HaveJsonBodyAs(&user{}, MatchPtrField("ID", 5)).Match(rr)
+		Expect(err).To(MatchError(HavePrefix("Decoding into *matchers_test.user. Error decoding body:")))
+	})
+	It("errors if the target is not a non-nil pointer", func() {
+		_, err := HaveJsonBodyAs(user{}, Not(BeNil())).Match(newRr(`{}`))
+		Expect(err).To(MatchError("HaveJsonBodyAs requires a non-nil pointer target, got matchers_test.user"))
+	})
+	It("can be negated", func() {
+		rr := newRr(`{"id": 5}`)
+		Expect(rr).ToNot(HaveJsonBodyAs(&user{}, MatchPtrField("ID", 6)))
+		matcher := &matchers.HaveJsonBodyAsMatcher{Target: &user{}, Inner: MatchPtrField("ID", 5)}
+		Expect(matcher.Match(rr)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(rr)).To(HavePrefix("Field ID of"))
+	})
+})
@@ -6,6 +6,8 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/rgalanakis/golangal"
 	"github.com/rgalanakis/golangal/matchers"
+	"io/ioutil"
+	"net/http"
 	"net/http/httptest"
 )
 
@@ -20,10 +22,20 @@ var _ = Describe("HaveJsonBodyMatcher", func() {
 		resp := newRr(`{"a": 1}`)
 		Expect(resp).To(HaveJsonBody(HaveKeyWithValue("a", BeEquivalentTo(1))))
 	})
+	It("can match an *http.Response and *http.Request, and restores the body", func() {
+		resp := &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(`{"a": 1}`))}
+		Expect(resp).To(HaveJsonBody(HaveKey("a")))
+		Expect(resp).To(HaveJsonBody(HaveKeyWithValue("a", BeEquivalentTo(1))))
+		Expect(ioutil.ReadAll(resp.Body)).To(MatchJSON(`{"a": 1}`))
+
+		req := httptest.NewRequest("POST", "/", bytes.NewBufferString(`[1, 2]`))
+		Expect(req).To(HaveJsonBody(HaveLen(2)))
+		Expect(ioutil.ReadAll(req.Body)).To(MatchJSON(`[1, 2]`))
+	})
 	It("errors for an invalid actual", func() {
 		success, err := (&matchers.HaveJsonBodyMatcher{}).Match(5)
 		Expect(success).To(BeFalse())
-		Expect(err).To(MatchError("actual must be a *httptest.ResponseRecorder"))
+		Expect(err).To(MatchError("actual must be a *httptest.ResponseRecorder, *http.Response, or *http.Request"))
 	})
 	It("fails if the matcher does not match the body", func() {
 		resp := newRr(`{"a": 1}`)
@@ -42,7 +54,46 @@ to have {key: value} matching
 		matcher := &matchers.HaveJsonBodyMatcher{}
 		success, err := matcher.Match(resp)
 		Expect(success).To(BeFalse())
-		Expect(err).ToNot(HaveOccurred())
-		Expect(matcher.FailureMessage(resp)).To(HavePrefix(`Error decoding body: unexpected end of JSON input`))
+		Expect(err).To(MatchError(HavePrefix(`Error decoding body: unexpected end of JSON input`)))
+	})
+})
+
+var _ = Describe("HaveJsonBodyMatcher negation", func() {
+	It("succeeds negated if the body does not match", func() {
+		rr := httptest.NewRecorder()
+		rr.WriteString(`{"a": 1}`)
+		Expect(rr).ToNot(HaveJsonBody(HaveKey("b")))
+	})
+
+	It("errors negated if the body is not json", func() {
+		for _, body := range []string{"", "<html>Internal Server Error</html>"} {
+			rr := httptest.NewRecorder()
+			rr.WriteString(body)
+			_, err := HaveJsonBody(HaveKey("debug")).Match(rr)
+			Expect(err).To(MatchError(HavePrefix("Error decoding body:")))
+		}
+	})
+
+	It("fails negated if the body matches", func() {
+		rr := httptest.NewRecorder()
+		rr.WriteString(`{"a": 1}`)
+		matcher := HaveJsonBody(HaveKey("a"))
+		Expect(matcher.Match(rr)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(rr)).To(HavePrefix(`Expected
+    <map[string]interface {} | len:1>: {"a": <float64>1}
+not to have key
+    <string>: a`))
+	})
+})
+
+var _ = Describe("HaveJsonBodyMatcher decode errors", func() {
+	It("includes the body near the error", func() {
+		rr := httptest.NewRecorder()
+		rr.WriteString(`{"a": 1, "b": x}`)
+		matcher := &matchers.HaveJsonBodyMatcher{}
+		_, err := matcher.Match(rr)
+		Expect(err).To(MatchError(`Error decoding body: invalid character 'x' looking for beginning of value
+Body near offset 15:
+{"a": 1, "b": x}`))
 	})
 })
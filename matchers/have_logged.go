@@ -0,0 +1,167 @@
+package matchers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"github.com/rgalanakis/golangal/internal"
+)
+
+// LogRecord is a captured log/slog record.
+type LogRecord struct {
+	Level   slog.Level
+	Message string
+	// Attrs are the attributes of the record, including those added with Logger.With.
+	// Keys of attributes inside groups are joined with '.', like "request.id".
+	Attrs map[string]interface{}
+}
+
+func (r LogRecord) String() string {
+	bld := &strings.Builder{}
+	bld.WriteString(r.Level.String())
+	bld.WriteString(" ")
+	bld.WriteString(fmt.Sprintf("%q", r.Message))
+	keys := make([]string, 0, len(r.Attrs))
+	for k := range r.Attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		bld.WriteString(fmt.Sprintf(" %s=%v", k, r.Attrs[k]))
+	}
+	return bld.String()
+}
+
+type logRecorder interface {
+	Records() []LogRecord
+}
+
+type HaveLoggedMatcher struct {
+	Level   interface{}
+	Message interface{}
+	// Attrs are alternating keys and values (or matchers), or slog.Attr values.
+	Attrs []interface{}
+
+	records []LogRecord
+	matched *LogRecord
+}
+
+func (m *HaveLoggedMatcher) Match(actual interface{}) (success bool, err error) {
+	switch t := actual.(type) {
+	case logRecorder:
+		m.records = t.Records()
+	case []LogRecord:
+		m.records = t
+	default:
+		return false, fmt.Errorf("HaveLogged matcher requires an actual of []LogRecord or a type with a Records() []LogRecord method. Got:\n%s",
+			format.Object(actual, 1))
+	}
+	attrMatchers, err := m.attrMatchers()
+	if err != nil {
+		return false, err
+	}
+	levelMatcher := internal.CoerceToEquivalentMatcher(m.Level)
+	messageMatcher := internal.CoerceToEquivalentMatcher(m.Message)
+	m.matched = nil
+	for i, r := range m.records {
+		if ok, err := levelMatcher.Match(r.Level); err != nil || !ok {
+			continue
+		}
+		if ok, err := messageMatcher.Match(r.Message); err != nil || !ok {
+			continue
+		}
+		if matchAttrs(r, attrMatchers) {
+			m.matched = &m.records[i]
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *HaveLoggedMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected to have logged a record matching\n%s\nRecorded:\n%s",
+		m.describe(), formatLogRecords(m.records))
+}
+
+func (m *HaveLoggedMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected not to have logged a record matching\n%s\nbut found:\n%s%s",
+		m.describe(), format.Indent, m.matched.String())
+}
+
+func (m *HaveLoggedMatcher) describe() string {
+	lines := []string{
+		"level: " + describeExpected(m.Level),
+		"message: " + describeExpected(m.Message),
+	}
+	for i := 0; i < len(m.Attrs); i++ {
+		if a, ok := m.Attrs[i].(slog.Attr); ok {
+			lines = append(lines, a.Key+": "+describeExpected(a.Value.Any()))
+		} else if i+1 < len(m.Attrs) {
+			lines = append(lines, fmt.Sprintf("%v: %s", m.Attrs[i], describeExpected(m.Attrs[i+1])))
+			i++
+		}
+	}
+	return format.IndentString(strings.Join(lines, "\n"), 1)
+}
+
+type attrMatcher struct {
+	key     string
+	matcher types.GomegaMatcher
+}
+
+func (m *HaveLoggedMatcher) attrMatchers() ([]attrMatcher, error) {
+	result := make([]attrMatcher, 0, len(m.Attrs))
+	for i := 0; i < len(m.Attrs); i++ {
+		if a, ok := m.Attrs[i].(slog.Attr); ok {
+			result = append(result, attrMatcher{key: a.Key, matcher: internal.CoerceToEquivalentMatcher(a.Value.Any())})
+			continue
+		}
+		key, ok := m.Attrs[i].(string)
+		if !ok || i+1 >= len(m.Attrs) {
+			return nil, errors.New("HaveLogged attrs must be slog.Attr values, or alternating string keys and values")
+		}
+		result = append(result, attrMatcher{key: key, matcher: internal.CoerceToEquivalentMatcher(m.Attrs[i+1])})
+		i++
+	}
+	return result, nil
+}
+
+func matchAttrs(r LogRecord, matchers []attrMatcher) bool {
+	for _, am := range matchers {
+		v, ok := r.Attrs[am.key]
+		if !ok {
+			return false
+		}
+		if ok, err := am.matcher.Match(v); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func describeExpected(i interface{}) string {
+	switch t := i.(type) {
+	case types.GomegaMatcher:
+		return format.Object(t, 0)
+	case string:
+		return fmt.Sprintf("%q", t)
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+func formatLogRecords(records []LogRecord) string {
+	if len(records) == 0 {
+		return format.Indent + "<none>"
+	}
+	lines := make([]string, len(records))
+	for i, r := range records {
+		lines[i] = format.Indent + r.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,67 @@
+package matchers_test
+
+import (
+	"log/slog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("HaveLoggedMatcher", func() {
+	records := []LogRecord{
+		{Level: slog.LevelInfo, Message: "started", Attrs: map[string]interface{}{"port": int64(8080)}},
+		{Level: slog.LevelWarn, Message: "slow request", Attrs: map[string]interface{}{"path": "/x", "ms": int64(1200)}},
+	}
+
+	It("matches level, message, and attrs", func() {
+		Expect(records).To(HaveLogged(slog.LevelInfo, "started"))
+		Expect(records).To(HaveLogged(slog.LevelWarn, ContainSubstring("slow"), "ms", BeNumerically(">", 1000), "path", "/x"))
+		Expect(records).To(HaveLogged(BeNumerically(">=", slog.LevelInfo), "started", slog.Int("port", 8080)))
+		Expect(records).ToNot(HaveLogged(slog.LevelError, "started"))
+		Expect(records).ToNot(HaveLogged(slog.LevelInfo, "started", "port", 80))
+		Expect(records).ToNot(HaveLogged(slog.LevelInfo, "started", "missing", 1))
+	})
+
+	It("errors for an invalid actual", func() {
+		success, err := HaveLogged(slog.LevelInfo, "x").Match(5)
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError(HavePrefix("HaveLogged matcher requires an actual of []LogRecord")))
+	})
+
+	It("errors for invalid attrs", func() {
+		success, err := HaveLogged(slog.LevelInfo, "x", "key").Match(records)
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError("HaveLogged attrs must be slog.Attr values, or alternating string keys and values"))
+	})
+
+	It("fails with the recorded records", func() {
+		matcher := HaveLogged(slog.LevelError, "started", "port", 80)
+		success, err := matcher.Match(records)
+		Expect(success).To(BeFalse())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matcher.FailureMessage(records)).To(Equal(`Expected to have logged a record matching
+    level: ERROR
+    message: "started"
+    port: 80
+Recorded:
+    INFO "started" port=8080
+    WARN "slow request" ms=1200 path=/x`))
+	})
+
+	It("fails if nothing was recorded", func() {
+		matcher := HaveLogged(slog.LevelError, "x")
+		Expect(matcher.Match([]LogRecord{})).To(BeFalse())
+		Expect(matcher.FailureMessage(nil)).To(HaveSuffix("Recorded:\n    <none>"))
+	})
+
+	It("fails negated with the matching record", func() {
+		matcher := HaveLogged(slog.LevelWarn, ContainSubstring("slow"))
+		Expect(matcher.Match(records)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(records)).To(MatchRegexp(`Expected not to have logged a record matching
+    level: WARN
+    message: <\*matchers.ContainSubstringMatcher \| 0x[0-9a-f]+>: {Substr: slow, Args: nil}
+but found:
+    WARN "slow request" ms=1200 path=/x`))
+	})
+})
@@ -0,0 +1,153 @@
+package matchers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"github.com/rgalanakis/golangal/internal"
+)
+
+type HaveProblemDetailsMatcher struct {
+	// Status is the expected HTTP status, like HaveResponseCodeMatcher.CodeOrMatcher.
+	Status interface{}
+	// Type and Title are values or matchers for the type and title members.
+	// If nil, they are not checked.
+	Type  interface{}
+	Title interface{}
+	// Extensions are alternating member names and values (or matchers).
+	Extensions []interface{}
+
+	codeMatcher *HaveResponseCodeMatcher
+	codeMatched bool
+	msg         *httpMessage
+	problem     string
+	decodeErr   error
+	violations  []string
+}
+
+var problemStringMembers = []string{"type", "title", "detail", "instance"}
+
+func (m *HaveProblemDetailsMatcher) Match(actual interface{}) (success bool, err error) {
+	if len(m.Extensions)%2 != 0 {
+		return false, errors.New("HaveProblemDetails extensions must be alternating member names and values")
+	}
+	m.problem, m.decodeErr, m.violations = "", nil, nil
+	m.codeMatcher = &HaveResponseCodeMatcher{CodeOrMatcher: m.Status}
+	if m.codeMatched, err = m.codeMatcher.Match(actual); err != nil || !m.codeMatched {
+		return false, err
+	}
+	if m.msg, err = requireResponseBody(actual); err != nil {
+		return false, err
+	}
+	m.problem = checkContentType(m.msg, "application/problem+json", func(mediaType string) bool {
+		return mediaType == "application/problem+json"
+	})
+	if m.problem != "" {
+		return false, nil
+	}
+	var members map[string]interface{}
+	if err := json.Unmarshal(m.msg.Body, &members); err != nil {
+		m.decodeErr = err
+		return false, nil
+	}
+	if members == nil {
+		m.problem = "Expected problem details to be a JSON object"
+		return false, nil
+	}
+
+	for _, name := range problemStringMembers {
+		if v, ok := members[name]; ok {
+			if _, isString := v.(string); !isString {
+				m.violate("member %q must be a string, got %s", name, format.Object(v, 0))
+			}
+		}
+	}
+	if status, ok := members["status"]; ok {
+		if f, isNumber := status.(float64); !isNumber {
+			m.violate("member \"status\" must be a number, got %s", format.Object(status, 0))
+		} else if int(f) != m.msg.Code {
+			m.violate("member \"status\" is %v, but the response status is %d", f, m.msg.Code)
+		}
+	}
+	// A missing type means "about:blank", per RFC 7807.
+	problemType, ok := members["type"]
+	if !ok {
+		problemType = "about:blank"
+	}
+	if err := m.matchMember("type", m.Type, problemType, true); err != nil {
+		return false, err
+	}
+	if title, ok := members["title"]; !ok {
+		m.violate("member \"title\" is missing")
+	} else if err := m.matchMember("title", m.Title, title, true); err != nil {
+		return false, err
+	}
+	for i := 0; i < len(m.Extensions); i += 2 {
+		name, ok := m.Extensions[i].(string)
+		if !ok {
+			return false, errors.New("HaveProblemDetails extensions must be alternating member names and values")
+		}
+		value, ok := members[name]
+		if !ok {
+			m.violate("extension member %q is missing", name)
+			continue
+		}
+		if err := m.matchMember(name, m.Extensions[i+1], value, false); err != nil {
+			return false, err
+		}
+	}
+	return len(m.violations) == 0, nil
+}
+
+// matchMember matches a member value against expected.
+// If expected is nil, an optional member is not checked, and other members must be null.
+func (m *HaveProblemDetailsMatcher) matchMember(name string, expected, actual interface{}, optional bool) error {
+	var inner types.GomegaMatcher
+	if expected == nil {
+		if optional {
+			return nil
+		}
+		inner = gomega.BeNil()
+	} else {
+		inner = internal.CoerceToEquivalentMatcher(expected)
+	}
+	ok, err := inner.Match(actual)
+	if err != nil {
+		return fmt.Errorf("matching member %q: %v", name, err)
+	}
+	if !ok {
+		m.violate("member %q did not match. %s", name, inner.FailureMessage(actual))
+	}
+	return nil
+}
+
+func (m *HaveProblemDetailsMatcher) violate(format string, args ...interface{}) {
+	m.violations = append(m.violations, fmt.Sprintf(format, args...))
+}
+
+func (m *HaveProblemDetailsMatcher) FailureMessage(actual interface{}) (message string) {
+	if !m.codeMatched {
+		return m.codeMatcher.FailureMessage(actual)
+	}
+	if m.problem != "" {
+		return m.problem + "\n" + m.msg.Describe()
+	}
+	if m.decodeErr != nil {
+		return decodeErrorMessage(m.decodeErr, m.msg.Body)
+	}
+	lines := make([]string, len(m.violations))
+	for i, v := range m.violations {
+		lines[i] = format.IndentString(v, 1)
+	}
+	return fmt.Sprintf("Expected matching problem details, but:\n%s\n%s",
+		strings.Join(lines, "\n"), m.msg.Describe())
+}
+
+func (m *HaveProblemDetailsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return "Expected not to have matching problem details, but did\n" + m.msg.Describe()
+}
@@ -0,0 +1,106 @@
+package matchers_test
+
+import (
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("HaveProblemDetailsMatcher", func() {
+	problem := func(code int, contentType, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Type", contentType)
+		rr.WriteHeader(code)
+		rr.WriteString(body)
+		return rr
+	}
+	valid := func() *httptest.ResponseRecorder {
+		return problem(422, "application/problem+json", `{
+"type": "https://example.com/probs/invalid",
+"title": "Invalid input",
+"status": 422,
+"detail": "name is required",
+"errors": [{"field": "name"}],
+"retryable": false,
+"attempts": 3
+}`)
+	}
+
+	It("matches the status, type, title, and extensions", func() {
+		Expect(valid()).To(HaveProblemDetails(422, "https://example.com/probs/invalid", "Invalid input"))
+		Expect(valid()).To(HaveProblemDetails(Status4xx, HaveSuffix("/invalid"), nil,
+			"errors", HaveLen(1), "retryable", false, "attempts", 3))
+		Expect(valid()).ToNot(HaveProblemDetails(400, nil, nil))
+		Expect(valid()).ToNot(HaveProblemDetails(422, nil, "Other"))
+		Expect(valid()).ToNot(HaveProblemDetails(422, nil, nil, "attempts", 4))
+	})
+
+	It("defaults a missing type to about:blank", func() {
+		rr := problem(404, "application/problem+json", `{"title": "Not Found"}`)
+		Expect(rr).To(HaveProblemDetails("Not Found", "about:blank", "Not Found"))
+	})
+
+	It("fails with the response code failure", func() {
+		rr := valid()
+		matcher := HaveProblemDetails(400, nil, nil)
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(HavePrefix("Expected\n    <int>: 422\nto be equivalent to\n    <int>: 400\nHeaders:"))
+	})
+
+	It("fails for the wrong content type", func() {
+		rr := problem(400, "application/json", `{"title": "Bad"}`)
+		matcher := HaveProblemDetails(400, nil, nil)
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(HavePrefix("Expected Content-Type application/problem+json, got application/json\nHeaders:"))
+	})
+
+	It("fails for invalid JSON or a non-object", func() {
+		matcher := HaveProblemDetails(400, nil, nil)
+		rr := problem(400, "application/problem+json", `{`)
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(HavePrefix("Error decoding body: unexpected end of JSON input"))
+
+		rr = problem(400, "application/problem+json", `null`)
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(HavePrefix("Expected problem details to be a JSON object\n"))
+	})
+
+	It("lists every problem with the members", func() {
+		rr := problem(409, "application/problem+json", `{"type": 5, "status": 410, "detail": "x", "code": "a"}`)
+		matcher := HaveProblemDetails(409, "about:blank", nil, "code", "b", "missing", true)
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(HavePrefix(`Expected matching problem details, but:
+    member "type" must be a string, got <float64>: 5
+    member "status" is 410, but the response status is 409
+    member "type" did not match. Expected
+        <float64>: 5
+    to be equivalent to
+        <string>: about:blank
+    member "title" is missing
+    member "code" did not match. Expected
+        <string>: a
+    to be equivalent to
+        <string>: b
+    extension member "missing" is missing
+Headers:
+    Content-Type: application/problem+json
+Body:
+{`))
+	})
+
+	It("errors for invalid extensions", func() {
+		_, err := HaveProblemDetails(422, nil, nil, "errors").Match(valid())
+		Expect(err).To(MatchError("HaveProblemDetails extensions must be alternating member names and values"))
+		_, err = HaveProblemDetails(422, nil, nil, 1, 2).Match(valid())
+		Expect(err).To(MatchError("HaveProblemDetails extensions must be alternating member names and values"))
+	})
+
+	It("fails negated with the body", func() {
+		rr := valid()
+		matcher := HaveProblemDetails(422, nil, nil)
+		Expect(matcher.Match(rr)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(rr)).To(HavePrefix("Expected not to have matching problem details, but did\nHeaders:"))
+	})
+})
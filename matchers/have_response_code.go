@@ -1,37 +1,115 @@
 package matchers
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
+
 	"github.com/onsi/gomega"
-	"net/http/httptest"
 )
 
+// StatusClass is a class of HTTP status codes, like 2xx (successful) or 4xx (client error).
+type StatusClass int
+
+const (
+	Status1xx StatusClass = iota + 1
+	Status2xx
+	Status3xx
+	Status4xx
+	Status5xx
+)
+
+func (c StatusClass) String() string {
+	return fmt.Sprintf("%dxx", int(c))
+}
+
+// Contains returns true if code is in the class.
+func (c StatusClass) Contains(code int) bool {
+	return code/100 == int(c)
+}
+
 type HaveResponseCodeMatcher struct {
+	// CodeOrMatcher is an int code, a StatusClass, a status name like "Not Found"
+	// (see http.StatusText), or a matcher for the int code.
 	CodeOrMatcher interface{}
 	inner         gomega.OmegaMatcher
-	rr            *httptest.ResponseRecorder
+	msg           *httpMessage
 }
 
 func (matcher *HaveResponseCodeMatcher) Match(actual interface{}) (bool, error) {
-	rr, err := requireRespRec(actual)
+	msg, err := requireResponse(actual)
 	if err != nil {
 		return false, err
 	}
-	if inner, ok := matcher.CodeOrMatcher.(gomega.OmegaMatcher); ok {
-		matcher.inner = inner
-	} else {
+	switch t := matcher.CodeOrMatcher.(type) {
+	case gomega.OmegaMatcher:
+		matcher.inner = t
+	case StatusClass:
+		matcher.inner = &statusMatcher{class: t}
+	case string:
+		code, ok := statusCodeNamed(t)
+		if !ok {
+			return false, fmt.Errorf("unknown HTTP status %q", t)
+		}
+		matcher.inner = &statusMatcher{code: code}
+	default:
 		matcher.inner = gomega.BeEquivalentTo(matcher.CodeOrMatcher)
 	}
-	matcher.rr = rr
-	return matcher.inner.Match(rr.Code)
+	matcher.msg = msg
+	return matcher.inner.Match(msg.Code)
 }
 
 func (matcher *HaveResponseCodeMatcher) FailureMessage(actual interface{}) (message string) {
-	msg := matcher.inner.FailureMessage(matcher.rr.Code)
-	//msg := format.Message(matcher.rr.Code, "to equal", matcher.CodeOrMatcher)
-	msg += "\nBody:\n" + matcher.rr.Body.String()
-	return msg
+	return matcher.inner.FailureMessage(matcher.msg.Code) + "\n" + matcher.msg.Describe()
 }
 
 func (matcher *HaveResponseCodeMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	panic(noNegate)
+	return matcher.inner.NegatedFailureMessage(matcher.msg.Code) + "\n" + matcher.msg.Describe()
+}
+
+// statusCodeNamed returns the code whose http.StatusText is name, ignoring case.
+func statusCodeNamed(name string) (int, bool) {
+	for code := 100; code < 600; code++ {
+		if text := http.StatusText(code); text != "" && strings.EqualFold(text, name) {
+			return code, true
+		}
+	}
+	return 0, false
+}
+
+// statusMatcher matches a code against a class or a single code,
+// describing codes with their status text.
+type statusMatcher struct {
+	class StatusClass
+	code  int
+}
+
+func (m *statusMatcher) Match(actual interface{}) (bool, error) {
+	code := actual.(int)
+	if m.class != 0 {
+		return m.class.Contains(code), nil
+	}
+	return code == m.code, nil
+}
+
+func (m *statusMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected status %s to be %s", describeStatus(actual.(int)), m.expected())
+}
+
+func (m *statusMatcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected status %s not to be %s", describeStatus(actual.(int)), m.expected())
+}
+
+func (m *statusMatcher) expected() string {
+	if m.class != 0 {
+		return m.class.String()
+	}
+	return describeStatus(m.code)
+}
+
+func describeStatus(code int) string {
+	if text := http.StatusText(code); text != "" {
+		return fmt.Sprintf("%d (%s)", code, text)
+	}
+	return fmt.Sprint(code)
 }
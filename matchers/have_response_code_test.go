@@ -6,6 +6,8 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/rgalanakis/golangal"
 	"github.com/rgalanakis/golangal/matchers"
+	"io/ioutil"
+	"net/http"
 	"net/http/httptest"
 )
 
@@ -18,10 +20,22 @@ var _ = Describe("HaveResponseCodeMatcher", func() {
 		rr := &httptest.ResponseRecorder{Code: 422}
 		Expect(rr).To(HaveResponseCode(BeNumerically(">", 400)))
 	})
+	It("can match an *http.Response", func() {
+		resp := &http.Response{StatusCode: 201, Body: ioutil.NopCloser(bytes.NewBufferString("created"))}
+		Expect(resp).To(HaveResponseCode(201))
+		matcher := &matchers.HaveResponseCodeMatcher{CodeOrMatcher: 200}
+		Expect(matcher.Match(resp)).To(BeFalse())
+		Expect(matcher.FailureMessage(resp)).To(HaveSuffix("Body:\ncreated"))
+	})
+	It("errors for an *http.Request", func() {
+		success, err := (&matchers.HaveResponseCodeMatcher{}).Match(httptest.NewRequest("GET", "/", nil))
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError("actual must be a *httptest.ResponseRecorder or *http.Response"))
+	})
 	It("errors for an invalid actual", func() {
 		success, err := (&matchers.HaveResponseCodeMatcher{}).Match(5)
 		Expect(success).To(BeFalse())
-		Expect(err).To(MatchError("actual must be a *httptest.ResponseRecorder"))
+		Expect(err).To(MatchError("actual must be a *httptest.ResponseRecorder or *http.Response"))
 	})
 	It("fails with a clear message", func() {
 		resp := &httptest.ResponseRecorder{Code: 422, Body: bytes.NewBufferString("abc")}
@@ -50,3 +64,88 @@ Body:
 <nil>`))
 	})
 })
+
+var _ = Describe("HaveResponseCodeMatcher negation", func() {
+	It("succeeds negated if the code does not match", func() {
+		Expect(&httptest.ResponseRecorder{Code: 200}).ToNot(HaveResponseCode(500))
+	})
+
+	It("fails negated with the code and body", func() {
+		resp := &httptest.ResponseRecorder{Code: 500, Body: bytes.NewBufferString("oops")}
+		matcher := HaveResponseCode(BeNumerically(">=", 500))
+		Expect(matcher.Match(resp)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(resp)).To(Equal(`Expected
+    <int>: 500
+not to be >=
+    <int>: 500
+Body:
+oops`))
+	})
+})
+
+var _ = Describe("HaveResponseCodeMatcher classes and names", func() {
+	It("can match a status class", func() {
+		Expect(&httptest.ResponseRecorder{Code: 204}).To(HaveResponseCode(Status2xx))
+		Expect(&httptest.ResponseRecorder{Code: 404}).To(HaveResponseCode(Status4xx))
+		Expect(&httptest.ResponseRecorder{Code: 404}).ToNot(HaveResponseCode(Status5xx))
+	})
+	It("can match a status name", func() {
+		Expect(&httptest.ResponseRecorder{Code: 404}).To(HaveResponseCode("Not Found"))
+		Expect(&httptest.ResponseRecorder{Code: 422}).To(HaveResponseCode("unprocessable entity"))
+		Expect(&httptest.ResponseRecorder{Code: 200}).ToNot(HaveResponseCode("Created"))
+	})
+	It("errors for an unknown status name", func() {
+		_, err := HaveResponseCode("Bad Stuff").Match(&httptest.ResponseRecorder{Code: 200})
+		Expect(err).To(MatchError(`unknown HTTP status "Bad Stuff"`))
+	})
+	It("describes classes and names with status text", func() {
+		rr := &httptest.ResponseRecorder{Code: 422, Body: bytes.NewBufferString("abc")}
+		matcher := HaveResponseCode(Status2xx)
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(Equal("Expected status 422 (Unprocessable Entity) to be 2xx\nBody:\nabc"))
+
+		matcher = HaveResponseCode("Unprocessable Entity")
+		Expect(matcher.Match(rr)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(rr)).To(Equal(
+			"Expected status 422 (Unprocessable Entity) not to be 422 (Unprocessable Entity)\nBody:\nabc"))
+	})
+})
+
+var _ = Describe("HaveResponseCodeMatcher failure output", func() {
+	It("prints headers and pretty-prints JSON bodies", func() {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Type", "application/json")
+		rr.Header().Add("Set-Cookie", "a=1")
+		rr.Header().Add("Set-Cookie", "b=2")
+		rr.WriteHeader(500)
+		rr.WriteString(`{"error": {"code": "oops"}}`)
+		matcher := HaveResponseCode(Status2xx)
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(Equal(`Expected status 500 (Internal Server Error) to be 2xx
+Headers:
+    Content-Type: application/json
+    Set-Cookie: a=1
+    Set-Cookie: b=2
+Body:
+{
+  "error": {
+    "code": "oops"
+  }
+}`))
+	})
+	It("truncates long bodies", func() {
+		original := matchers.MaxFailureBodyLen
+		DeferCleanup(func() { matchers.MaxFailureBodyLen = original })
+		matchers.MaxFailureBodyLen = 5
+		rr := &httptest.ResponseRecorder{Code: 500, Body: bytes.NewBufferString("0123456789")}
+		matcher := HaveResponseCode(200)
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(HaveSuffix("Body:\n01234\n... (truncated, 10 bytes total)"))
+	})
+	It("prints invalid JSON as is", func() {
+		rr := &httptest.ResponseRecorder{Code: 500, Body: bytes.NewBufferString(`{"a": `)}
+		matcher := HaveResponseCode(200)
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(HaveSuffix("Body:\n{\"a\": "))
+	})
+})
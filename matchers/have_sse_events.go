@@ -0,0 +1,241 @@
+package matchers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// SSEEvent is an event parsed from a text/event-stream body.
+type SSEEvent struct {
+	// ID is the last event ID, which carries over from earlier events, like in browsers.
+	ID string
+	// Event is the event type, which is "message" if the event has no event field.
+	Event string
+	// Data is the data lines of the event, joined with newlines.
+	Data string
+	// Retry is the reconnection time in milliseconds from the event's retry field, or 0.
+	Retry int
+}
+
+func (e SSEEvent) String() string {
+	s := fmt.Sprintf("event=%s data=%q", e.Event, e.Data)
+	if e.ID != "" {
+		s = fmt.Sprintf("id=%s %s", e.ID, s)
+	}
+	if e.Retry != 0 {
+		s += fmt.Sprintf(" retry=%d", e.Retry)
+	}
+	return s
+}
+
+// ParseSSE returns the events in a text/event-stream body.
+// An event that is not yet terminated by a blank line is not returned,
+// nor are events without data, following the HTML event stream parsing rules.
+func ParseSSE(body []byte) []SSEEvent {
+	text := strings.ReplaceAll(string(body), "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	var events []SSEEvent
+	var data []string
+	var eventType, lastID string
+	retry := 0
+	for {
+		idx := strings.IndexByte(text, '\n')
+		if idx < 0 {
+			break
+		}
+		line := text[:idx]
+		text = text[idx+1:]
+		if line == "" {
+			if data != nil {
+				if eventType == "" {
+					eventType = "message"
+				}
+				events = append(events, SSEEvent{ID: lastID, Event: eventType, Data: strings.Join(data, "\n"), Retry: retry})
+			}
+			data, eventType, retry = nil, "", 0
+			continue
+		}
+		if line[0] == ':' {
+			continue
+		}
+		field, value := line, ""
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				lastID = value
+			}
+		case "retry":
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 && !strings.HasPrefix(value, "+") {
+				retry = n
+			}
+		}
+	}
+	return events
+}
+
+// SSEStream reads a text/event-stream body in the background,
+// so events can be matched while the connection is still open.
+type SSEStream struct {
+	header http.Header
+	mu     sync.Mutex
+	data   []byte
+	err    error
+}
+
+// NewSSEStream starts reading body in the background, until it returns an error (like io.EOF).
+// Close body to stop reading.
+// header is the header of the response, used to check the Content-Type.
+func NewSSEStream(header http.Header, body io.Reader) *SSEStream {
+	s := &SSEStream{header: header}
+	go s.read(body)
+	return s
+}
+
+func (s *SSEStream) read(r io.Reader) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		s.mu.Lock()
+		s.data = append(s.data, buf[:n]...)
+		if err != nil && err != io.EOF {
+			s.err = err
+		}
+		s.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Events returns the events received so far.
+func (s *SSEStream) Events() []SSEEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ParseSSE(s.data)
+}
+
+// Err returns the error reading the body, if any. Reaching the end of the body is not an error.
+func (s *SSEStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+type HaveSSEEventsMatcher struct {
+	// Events are SSEEvent values, strings (matched against Data), or matchers of SSEEvent.
+	Events []interface{}
+	// AnyOrder matches the events as a set, rather than in order.
+	AnyOrder bool
+
+	events             []SSEEvent
+	contentTypeProblem string
+	inner              types.GomegaMatcher
+	failingIdx         int
+}
+
+func (m *HaveSSEEventsMatcher) Match(actual interface{}) (success bool, err error) {
+	m.contentTypeProblem = ""
+	m.inner = nil
+	switch t := actual.(type) {
+	case []SSEEvent:
+		m.events = t
+	case *SSEStream:
+		if err := t.Err(); err != nil {
+			return false, err
+		}
+		m.contentTypeProblem = checkSSEContentType(&httpMessage{Header: t.header})
+		m.events = t.Events()
+	default:
+		msg, err := requireResponseBody(actual)
+		if err != nil && err.Error() == requireResponseMsg {
+			return false, fmt.Errorf("HaveSSEEvents matcher requires a *httptest.ResponseRecorder, *http.Response, *SSEStream, or []SSEEvent. Got:\n%s",
+				format.Object(actual, 1))
+		} else if err != nil {
+			return false, err
+		}
+		m.contentTypeProblem = checkSSEContentType(msg)
+		m.events = ParseSSE(msg.Body)
+	}
+	if m.contentTypeProblem != "" {
+		return false, nil
+	}
+	matchers := make([]interface{}, len(m.Events))
+	for i, e := range m.Events {
+		matchers[i] = sseEventMatcher(e)
+	}
+	if m.AnyOrder {
+		m.inner = gomega.ConsistOf(matchers...)
+		return m.inner.Match(m.events)
+	}
+	if len(m.events) != len(matchers) {
+		return false, nil
+	}
+	for i, e := range m.events {
+		inner := matchers[i].(types.GomegaMatcher)
+		if ok, err := inner.Match(e); err != nil || !ok {
+			m.inner, m.failingIdx = inner, i
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (m *HaveSSEEventsMatcher) FailureMessage(actual interface{}) (message string) {
+	if m.contentTypeProblem != "" {
+		return m.contentTypeProblem
+	}
+	if m.AnyOrder {
+		return m.inner.FailureMessage(m.events)
+	}
+	if m.inner == nil {
+		return fmt.Sprintf("Expected %d SSE events, got %d:\n%s", len(m.Events), len(m.events), formatSSEEvents(m.events))
+	}
+	return fmt.Sprintf("SSE event %d did not match. %s\nEvents:\n%s",
+		m.failingIdx, m.inner.FailureMessage(m.events[m.failingIdx]), formatSSEEvents(m.events))
+}
+
+func (m *HaveSSEEventsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return "Expected SSE events not to match, but got:\n" + formatSSEEvents(m.events)
+}
+
+func checkSSEContentType(msg *httpMessage) string {
+	return checkContentType(msg, "text/event-stream", func(mediaType string) bool {
+		return mediaType == "text/event-stream"
+	})
+}
+
+func sseEventMatcher(e interface{}) types.GomegaMatcher {
+	switch t := e.(type) {
+	case types.GomegaMatcher:
+		return t
+	case string:
+		return &MatchFieldMatcher{Name: "Data", Matcher: gomega.Equal(t)}
+	}
+	return gomega.Equal(e)
+}
+
+func formatSSEEvents(events []SSEEvent) string {
+	if len(events) == 0 {
+		return format.Indent + "<none>"
+	}
+	lines := make([]string, len(events))
+	for i, e := range events {
+		lines[i] = format.Indent + e.String()
+	}
+	return strings.Join(lines, "\n")
+}
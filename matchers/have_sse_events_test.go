@@ -0,0 +1,105 @@
+package matchers_test
+
+import (
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+	"github.com/rgalanakis/golangal/matchers"
+)
+
+var _ = Describe("ParseSSE", func() {
+	It("parses events following the event stream rules", func() {
+		body := ": comment\r\n" +
+			"id: 1\r\nevent: update\r\ndata: line1\r\ndata:line2\r\nretry: 3000\r\n\r\n" +
+			"data: no id or type\n\n" +
+			"id: 2\nevent: empty\n\n" +
+			"data\rid\r\r" +
+			"retry: x\ndata: {\"a\": 1}\n\n" +
+			"data: unterminated\n"
+		Expect(matchers.ParseSSE([]byte(body))).To(Equal([]SSEEvent{
+			{ID: "1", Event: "update", Data: "line1\nline2", Retry: 3000},
+			{ID: "1", Event: "message", Data: "no id or type"},
+			{ID: "", Event: "message", Data: ""},
+			{ID: "", Event: "message", Data: `{"a": 1}`},
+		}))
+	})
+})
+
+var _ = Describe("HaveSSEEventsMatcher", func() {
+	stream := func(body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Type", "text/event-stream")
+		rr.WriteString(body)
+		return rr
+	}
+	body := "id: 1\nevent: greet\ndata: hello\n\ndata: world\n\n"
+
+	It("matches events in order", func() {
+		Expect(stream(body)).To(HaveSSEEvents("hello", "world"))
+		Expect(stream(body)).To(HaveSSEEvents(
+			SSEEvent{ID: "1", Event: "greet", Data: "hello"},
+			MatchField("Event", "message"),
+		))
+		Expect(stream(body)).ToNot(HaveSSEEvents("world", "hello"))
+		Expect(stream(body)).ToNot(HaveSSEEvents("hello"))
+	})
+
+	It("matches events in any order", func() {
+		Expect(stream(body)).To(HaveSSEEventsInAnyOrder("world", "hello"))
+		Expect(stream(body)).ToNot(HaveSSEEventsInAnyOrder("world"))
+	})
+
+	It("matches an *http.Response and []SSEEvent", func() {
+		Expect(stream(body).Result()).To(HaveSSEEvents("hello", "world"))
+		Expect([]SSEEvent{{Event: "message", Data: "x"}}).To(HaveSSEEvents("x"))
+	})
+
+	It("fails if the count is different", func() {
+		rr := stream(body)
+		matcher := HaveSSEEvents("hello")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(Equal(`Expected 1 SSE events, got 2:
+    id=1 event=greet data="hello"
+    id=1 event=message data="world"`))
+	})
+
+	It("fails with the index of the event that did not match", func() {
+		rr := stream(body)
+		matcher := HaveSSEEvents("hello", "there")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(HavePrefix(`SSE event 1 did not match. Field Data of`))
+		Expect(matcher.FailureMessage(rr)).To(HaveSuffix(`Events:
+    id=1 event=greet data="hello"
+    id=1 event=message data="world"`))
+	})
+
+	It("fails for the wrong content type", func() {
+		rr := stream(body)
+		rr.Header().Set("Content-Type", "text/plain")
+		matcher := HaveSSEEvents("hello", "world")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(Equal("Expected Content-Type text/event-stream, got text/plain"))
+	})
+
+	It("errors for an invalid actual", func() {
+		_, err := HaveSSEEvents().Match(5)
+		Expect(err).To(MatchError(HavePrefix("HaveSSEEvents matcher requires a *httptest.ResponseRecorder, *http.Response, *SSEStream, or []SSEEvent.")))
+	})
+
+	It("errors for a body that cannot be decoded", func() {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Type", "text/event-stream")
+		rr.Header().Set("Content-Encoding", "gzip")
+		rr.WriteString("data: x\n\n")
+		_, err := HaveSSEEvents().Match(rr)
+		Expect(err).To(MatchError("decoding Content-Encoding gzip: unexpected EOF"))
+	})
+
+	It("fails negated with the events", func() {
+		matcher := HaveSSEEvents()
+		Expect(matcher.Match(stream(""))).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(nil)).To(Equal("Expected SSE events not to match, but got:\n    <none>"))
+	})
+})
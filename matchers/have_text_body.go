@@ -0,0 +1,57 @@
+package matchers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/onsi/gomega/types"
+)
+
+type HaveTextBodyMatcher struct {
+	Inner types.GomegaMatcher
+
+	body string
+}
+
+// Match errors if the Content-Type is not text or the body is not valid UTF-8,
+// like HaveJsonBodyMatcher.
+func (matcher *HaveTextBodyMatcher) Match(actual interface{}) (bool, error) {
+	msg, err := requireHttpBody(actual)
+	if err != nil {
+		return false, err
+	}
+	problem := checkContentType(msg, "text/*", func(mediaType string) bool {
+		return strings.HasPrefix(mediaType, "text/")
+	})
+	if problem != "" {
+		return false, errors.New(problem)
+	}
+	if !utf8.Valid(msg.Body) {
+		offset := invalidUTF8Offset(msg.Body)
+		err := fmt.Errorf("invalid UTF-8 at offset %d", offset)
+		return false, errors.New(decodeErrorMessageAt(err, msg.Body, int64(offset)))
+	}
+	matcher.body = string(msg.Body)
+	return matcher.Inner.Match(matcher.body)
+}
+
+func (matcher *HaveTextBodyMatcher) FailureMessage(actual interface{}) (message string) {
+	return matcher.Inner.FailureMessage(matcher.body)
+}
+
+func (matcher *HaveTextBodyMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return matcher.Inner.NegatedFailureMessage(matcher.body)
+}
+
+func invalidUTF8Offset(b []byte) int {
+	for i := 0; i < len(b); {
+		r, size := utf8.DecodeRune(b[i:])
+		if r == utf8.RuneError && size == 1 {
+			return i
+		}
+		i += size
+	}
+	return -1
+}
@@ -0,0 +1,48 @@
+package matchers_test
+
+import (
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("HaveTextBodyMatcher", func() {
+	newRr := func(contentType, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Type", contentType)
+		rr.WriteString(body)
+		return rr
+	}
+
+	It("matches the body as a string", func() {
+		Expect(newRr("text/plain; charset=utf-8", "pong")).To(HaveTextBody("pong"))
+		Expect(newRr("text/html", "<p>hi</p>")).To(HaveTextBody(ContainSubstring("hi")))
+		Expect(newRr("text/plain", "pong")).ToNot(HaveTextBody("ping"))
+	})
+
+	It("errors for the wrong content type or charset", func() {
+		matcher := HaveTextBody("{}")
+		_, err := matcher.Match(newRr("application/json", "{}"))
+		Expect(err).To(MatchError("Expected Content-Type text/*, got application/json"))
+
+		_, err = matcher.Match(newRr("text/plain; charset=Shift_JIS", "x"))
+		Expect(err).To(MatchError("Expected charset utf-8, got Shift_JIS"))
+	})
+
+	It("errors for invalid UTF-8", func() {
+		_, err := HaveTextBody("abcd").Match(newRr("text/plain", "ab\xffcd"))
+		Expect(err).To(MatchError("Error decoding body: invalid UTF-8 at offset 2\nBody near offset 2:\nab\xffcd"))
+	})
+
+	It("fails with the inner message", func() {
+		rr := newRr("text/plain", "pong")
+		matcher := HaveTextBody("ping")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(Equal("Expected\n    <string>: pong\nto equal\n    <string>: ping"))
+		matcher = HaveTextBody("pong")
+		Expect(matcher.Match(rr)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(rr)).To(ContainSubstring("not to equal"))
+	})
+})
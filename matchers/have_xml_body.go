@@ -0,0 +1,54 @@
+package matchers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+)
+
+type HaveXMLBodyMatcher struct {
+	// Target is a non-nil pointer the body is decoded into.
+	// It is passed to Inner after decoding.
+	Target interface{}
+	Inner  types.GomegaMatcher
+}
+
+// Match errors if the Content-Type is not XML or the body cannot be decoded,
+// like HaveJsonBodyAsMatcher.
+func (matcher *HaveXMLBodyMatcher) Match(actual interface{}) (bool, error) {
+	msg, err := requireHttpBody(actual)
+	if err != nil {
+		return false, err
+	}
+	target := reflect.ValueOf(matcher.Target)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return false, fmt.Errorf("HaveXMLBody requires a non-nil pointer target, got %T", matcher.Target)
+	}
+	if problem := checkContentType(msg, "application/xml, text/xml, or */*+xml", isXMLMediaType); problem != "" {
+		return false, errors.New(problem)
+	}
+	target.Elem().Set(reflect.Zero(target.Elem().Type()))
+	dec := xml.NewDecoder(bytes.NewReader(msg.Body))
+	if err := dec.Decode(matcher.Target); err != nil {
+		return false, fmt.Errorf("Decoding into %T. %s", matcher.Target,
+			decodeErrorMessageAt(err, msg.Body, dec.InputOffset()))
+	}
+	return matcher.Inner.Match(matcher.Target)
+}
+
+func (matcher *HaveXMLBodyMatcher) FailureMessage(actual interface{}) (message string) {
+	return matcher.Inner.FailureMessage(matcher.Target)
+}
+
+func (matcher *HaveXMLBodyMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return matcher.Inner.NegatedFailureMessage(matcher.Target)
+}
+
+func isXMLMediaType(mediaType string) bool {
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
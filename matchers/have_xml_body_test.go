@@ -0,0 +1,74 @@
+package matchers_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("HaveXMLBodyMatcher", func() {
+	type user struct {
+		XMLName xml.Name `xml:"user"`
+		ID      int      `xml:"id,attr"`
+		Name    string   `xml:"name"`
+	}
+	newRr := func(contentType, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		rr.Header().Set("Content-Type", contentType)
+		rr.WriteString(body)
+		return rr
+	}
+
+	It("decodes into the target and matches it", func() {
+		var u user
+		rr := newRr("application/xml; charset=UTF-8", `<user id="5"><name>Rob</name></user>`)
+		Expect(rr).To(HaveXMLBody(&u, MatchPtrField("Name", "Rob")))
+		Expect(u.ID).To(Equal(5))
+		Expect(newRr("text/xml", `<user id="5"/>`)).To(HaveXMLBody(&u, MatchPtrField("ID", 5)))
+		Expect(newRr("application/atom+xml", `<user id="6"/>`)).To(HaveXMLBody(&u, MatchPtrField("ID", 6)))
+		Expect(newRr("text/xml", `<user id="5"/>`)).ToNot(HaveXMLBody(&u, MatchPtrField("ID", 6)))
+	})
+
+	It("can match a request", func() {
+		req := httptest.NewRequest("POST", "/", bytes.NewBufferString(`<user id="5"/>`))
+		req.Header.Set("Content-Type", "application/xml")
+		Expect(req).To(HaveXMLBody(&user{}, MatchPtrField("ID", 5)))
+	})
+
+	DescribeTable("errors for the wrong content type or charset",
+		func(contentType, msg string) {
+			rr := newRr(contentType, `<user/>`)
+			if contentType == "" {
+				rr.Header().Del("Content-Type")
+			}
+			_, err := HaveXMLBody(&user{}, Not(BeNil())).Match(rr)
+			Expect(err).To(MatchError(msg))
+		},
+		Entry("missing", "", "Expected Content-Type application/xml, text/xml, or */*+xml, but there was none"),
+		Entry("json", "application/json", "Expected Content-Type application/xml, text/xml, or */*+xml, got application/json"),
+		Entry("invalid", "text/xml; =", `Expected Content-Type application/xml, text/xml, or */*+xml, but "text/xml; =" is invalid: mime: invalid media parameter`),
+		Entry("charset", "text/xml; charset=iso-8859-1", "Expected charset utf-8, got iso-8859-1"),
+	)
+
+	It("errors with the body near a decode error", func() {
+		rr := newRr("application/xml", `<user id="5"><name>Rob</nam></user>`)
+		_, err := HaveXMLBody(&user{}, Not(BeNil())).Match(rr)
+		Expect(err).To(MatchError(`Decoding into *matchers_test.user. Error decoding body: XML syntax error on line 1: element <name> closed by </nam>
+Body near offset 28:
+<user id="5"><name>Rob</nam></user>`))
+	})
+
+	It("errors negated for a non-XML body", func() {
+		_, err := HaveXMLBody(&user{}, MatchPtrField("ID", 5)).Match(newRr("text/html", "<html></html>"))
+		Expect(err).To(MatchError("Expected Content-Type application/xml, text/xml, or */*+xml, got text/html"))
+	})
+
+	It("errors if the target is not a non-nil pointer", func() {
+		_, err := HaveXMLBody(user{}, Not(BeNil())).Match(newRr("text/xml", `<user/>`))
+		Expect(err).To(MatchError("HaveXMLBody requires a non-nil pointer target, got matchers_test.user"))
+	})
+})
@@ -1,20 +1,241 @@
 package matchers
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
 )
 
-func requireRespRec(actual interface{}) (*httptest.ResponseRecorder, error) {
-	rr, ok := actual.(*httptest.ResponseRecorder)
-	if !ok {
-		return nil, errors.New("actual must be a *httptest.ResponseRecorder")
+// httpMessage is the part of a *httptest.ResponseRecorder, *http.Response,
+// or *http.Request that the HTTP matchers look at.
+type httpMessage struct {
+	// Code is the status code of a response. It is 0 for requests.
+	Code   int
+	Header http.Header
+	// Body is the body with any Content-Encoding (like gzip) decoded.
+	Body []byte
+	// RawBody is the body as it was sent, before decoding any Content-Encoding.
+	RawBody []byte
+	// URL is the URL of the request, if known.
+	URL      *url.URL
+	response bool
+	// bodyErr is the error decoding the Content-Encoding, if any.
+	// When it is set, Body is the same as RawBody.
+	bodyErr error
+}
+
+// BodyString returns the body as a string, or "<nil>" if there is no body.
+func (m *httpMessage) BodyString() string {
+	if m.Body == nil {
+		return "<nil>"
+	}
+	return string(m.Body)
+}
+
+// MaxFailureBodyLen is the most bytes of a body printed in failure messages
+// that describe a whole message, like HaveResponseCode's.
+var MaxFailureBodyLen = 4096
+
+// Describe returns the headers (if there are any) and body for failure messages.
+// JSON bodies are pretty-printed, and bodies are truncated to MaxFailureBodyLen bytes.
+func (m *httpMessage) Describe() string {
+	bld := &strings.Builder{}
+	if len(m.Header) > 0 {
+		keys := make([]string, 0, len(m.Header))
+		for k := range m.Header {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		bld.WriteString("Headers:\n")
+		for _, k := range keys {
+			for _, v := range m.Header[k] {
+				fmt.Fprintf(bld, "%s%s: %s\n", format.Indent, k, v)
+			}
+		}
+	}
+	bld.WriteString("Body:\n")
+	body := m.Body
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		indented := &bytes.Buffer{}
+		if json.Indent(indented, trimmed, "", "  ") == nil {
+			body = indented.Bytes()
+		}
+	}
+	if body == nil {
+		bld.WriteString("<nil>")
+	} else if len(body) > MaxFailureBodyLen {
+		bld.Write(body[:MaxFailureBodyLen])
+		fmt.Fprintf(bld, "\n... (truncated, %d bytes total)", len(body))
+	} else {
+		bld.Write(body)
+	}
+	return bld.String()
+}
+
+const requireHttpMessageMsg = "actual must be a *httptest.ResponseRecorder, *http.Response, or *http.Request"
+const requireResponseMsg = "actual must be a *httptest.ResponseRecorder or *http.Response"
+
+// requireHttpMessage returns the httpMessage for a response recorder, response, or request.
+// The body of a response or request is read, and replaced so it can be read again.
+// The body is decoded according to its Content-Encoding (see decodeContentEncoding),
+// but errors doing so are only returned from requireHttpBody,
+// since matchers that do not look at the body should still work.
+func requireHttpMessage(actual interface{}) (*httpMessage, error) {
+	var m *httpMessage
+	switch t := actual.(type) {
+	case *httptest.ResponseRecorder:
+		m = &httpMessage{Code: t.Code, Header: t.Header(), response: true}
+		if t.Body != nil {
+			m.RawBody = t.Body.Bytes()
+		}
+	case *http.Response:
+		body, err := readAndRestore(&t.Body)
+		if err != nil {
+			return nil, err
+		}
+		m = &httpMessage{Code: t.StatusCode, Header: t.Header, RawBody: body, response: true}
+		if t.Request != nil {
+			m.URL = t.Request.URL
+		}
+	case *http.Request:
+		body, err := readAndRestore(&t.Body)
+		if err != nil {
+			return nil, err
+		}
+		m = &httpMessage{Header: t.Header, RawBody: body, URL: t.URL}
+	default:
+		return nil, errors.New(requireHttpMessageMsg)
+	}
+	m.Body, m.bodyErr = decodeContentEncoding(m.Header, m.RawBody)
+	if m.bodyErr != nil {
+		m.Body = m.RawBody
+	}
+	return m, nil
+}
+
+// requireHttpBody is like requireHttpMessage, but also errors
+// if the body cannot be decoded according to its Content-Encoding.
+func requireHttpBody(actual interface{}) (*httpMessage, error) {
+	m, err := requireHttpMessage(actual)
+	if err != nil {
+		return nil, err
+	}
+	return m, m.bodyErr
+}
+
+// requireResponse is like requireHttpMessage, but does not allow requests.
+func requireResponse(actual interface{}) (*httpMessage, error) {
+	if _, ok := actual.(*http.Request); ok {
+		return nil, errors.New(requireResponseMsg)
+	}
+	m, err := requireHttpMessage(actual)
+	if err != nil && err.Error() == requireHttpMessageMsg {
+		return nil, errors.New(requireResponseMsg)
+	}
+	return m, err
+}
+
+// requireResponseBody is like requireResponse, but also errors
+// if the body cannot be decoded according to its Content-Encoding.
+func requireResponseBody(actual interface{}) (*httpMessage, error) {
+	m, err := requireResponse(actual)
+	if err != nil {
+		return nil, err
+	}
+	return m, m.bodyErr
+}
+
+// readAndRestore reads all of body, and replaces it with a reader over the same bytes,
+// so multiple matchers (and the test) can read it.
+func readAndRestore(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	b, err := ioutil.ReadAll(*body)
+	_ = (*body).Close()
+	*body = ioutil.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
 	}
-	return rr, nil
+	return b, nil
+}
+
+const bodySnippetLen = 200
+
+// decodeErrorMessage describes an error decoding a body, including the part of the body
+// where decoding failed, or the start of the body if the position is not known.
+func decodeErrorMessage(err error, body []byte) string {
+	offset := int64(-1)
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) {
+		offset = syntaxErr.Offset
+	} else if errors.As(err, &typeErr) {
+		offset = typeErr.Offset
+	}
+	return decodeErrorMessageAt(err, body, offset)
+}
+
+// decodeErrorMessageAt is like decodeErrorMessage, for an error at offset in body,
+// or -1 if the offset is not known.
+func decodeErrorMessageAt(err error, body []byte, offset int64) string {
+	return fmt.Sprintf("Error decoding body: %+v\n%s", err, bodySnippet(body, offset))
 }
 
-func mustRespSec(actual interface{}) *httptest.ResponseRecorder {
-	return actual.(*httptest.ResponseRecorder)
+// checkContentType returns a description of the problem if the Content-Type of msg
+// is not a media type accepted by ok, or has a charset other than UTF-8 (or its subset, US-ASCII).
+// expected describes the accepted media types.
+func checkContentType(msg *httpMessage, expected string, ok func(mediaType string) bool) string {
+	contentType := msg.Header.Get("Content-Type")
+	if contentType == "" {
+		return fmt.Sprintf("Expected Content-Type %s, but there was none", expected)
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Sprintf("Expected Content-Type %s, but %q is invalid: %v", expected, contentType, err)
+	}
+	if !ok(mediaType) {
+		return fmt.Sprintf("Expected Content-Type %s, got %s", expected, contentType)
+	}
+	if charset, ok := params["charset"]; ok && !strings.EqualFold(charset, "utf-8") && !strings.EqualFold(charset, "us-ascii") {
+		return fmt.Sprintf("Expected charset utf-8, got %s", charset)
+	}
+	return ""
 }
 
-const noNegate = "do not negate this matcher"
+func bodySnippet(body []byte, offset int64) string {
+	label := "Body:"
+	start, end := 0, len(body)
+	if offset >= 0 {
+		label = fmt.Sprintf("Body near offset %d:", offset)
+		start = int(offset) - bodySnippetLen/2
+		end = int(offset) + bodySnippetLen/2
+	} else {
+		end = bodySnippetLen
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end > len(body) {
+		end = len(body)
+	}
+	snippet := string(body[start:end])
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(body) {
+		snippet += "..."
+	}
+	return label + "\n" + snippet
+}
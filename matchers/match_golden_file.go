@@ -0,0 +1,123 @@
+package matchers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/onsi/gomega/format"
+	"github.com/rgalanakis/golangal/internal"
+)
+
+type MatchGoldenFileMatcher struct {
+	// Path is the path to the golden file.
+	Path string
+	// Update causes the golden file to be (re)written with the actual value,
+	// rather than compared against it.
+	Update bool
+	// UpdateHint is included in the failure message to explain how to update golden files.
+	UpdateHint string
+
+	actual   string
+	expected string
+	missing  bool
+}
+
+func (m *MatchGoldenFileMatcher) Match(actual interface{}) (success bool, err error) {
+	m.actual, err = goldenContents(actual)
+	if err != nil {
+		return false, err
+	}
+	if m.Update {
+		if err := os.MkdirAll(filepath.Dir(m.Path), 0755); err != nil {
+			return false, err
+		}
+		return true, ioutil.WriteFile(m.Path, []byte(m.actual), 0644)
+	}
+	expected, err := ioutil.ReadFile(m.Path)
+	if os.IsNotExist(err) {
+		m.missing = true
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	// Hand-written golden files may not be normalized, so normalize them like the actual.
+	m.expected = normalizeJson(expected)
+	return m.expected == m.actual, nil
+}
+
+func (m *MatchGoldenFileMatcher) FailureMessage(actual interface{}) (message string) {
+	if m.missing {
+		return fmt.Sprintf("Golden file %s does not exist%s", m.Path, m.UpdateHint)
+	}
+	return fmt.Sprintf("Expected actual to match golden file %s%s\n%s",
+		m.Path, m.UpdateHint, internal.UnifiedDiff(m.Path, "actual", m.expected, m.actual))
+}
+
+func (m *MatchGoldenFileMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected actual not to match golden file %s", m.Path)
+}
+
+// goldenContents converts actual into the string stored in a golden file.
+// JSON objects and arrays are re-encoded so key order and whitespace are consistent.
+// Other values are encoded as JSON, and normalized the same way.
+func goldenContents(actual interface{}) (string, error) {
+	var b []byte
+	switch t := actual.(type) {
+	case string:
+		b = []byte(t)
+	case []byte:
+		b = t
+	case json.RawMessage:
+		b = t
+	case *httptest.ResponseRecorder, *http.Response:
+		msg, err := requireHttpBody(t)
+		if err != nil {
+			return "", err
+		}
+		b = msg.Body
+	default:
+		encoded, err := marshalIndent(actual)
+		if err != nil {
+			return "", fmt.Errorf("MatchGoldenFile cannot encode actual as JSON: %s\n%s", err, format.Object(actual, 1))
+		}
+		b = []byte(encoded)
+	}
+	return normalizeJson(b), nil
+}
+
+func normalizeJson(b []byte) string {
+	trimmed := bytes.TrimSpace(b)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return string(b)
+	}
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return string(b)
+	}
+	encoded, err := marshalIndent(v)
+	if err != nil {
+		return string(b)
+	}
+	return encoded
+}
+
+// marshalIndent is like json.MarshalIndent, but does not escape HTML characters,
+// so golden files stay readable.
+func marshalIndent(v interface{}) (string, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,128 @@
+package matchers_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http/httptest"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+	"github.com/rgalanakis/golangal/matchers"
+)
+
+var _ = Describe("MatchGoldenFileMatcher", func() {
+	rootdir := EachFileTree(FileTree{
+		"text.golden":    "line1\nline2\nline3\n",
+		"json.golden":    "{\n  \"a\": 1,\n  \"b\": [\n    \"<x>\"\n  ]\n}\n",
+		"compact.golden": `{"a":1,"b":2}`,
+	})
+	golden := func(name string) *matchers.MatchGoldenFileMatcher {
+		return &matchers.MatchGoldenFileMatcher{Path: filepath.Join(rootdir(), name), UpdateHint: " (hint)"}
+	}
+
+	It("matches strings and bytes", func() {
+		Expect("line1\nline2\nline3\n").To(golden("text.golden"))
+		Expect([]byte("line1\nline2\nline3\n")).To(golden("text.golden"))
+		Expect("line1\n").ToNot(golden("text.golden"))
+	})
+
+	It("normalizes JSON key order and whitespace", func() {
+		Expect(`{"b": ["<x>"], "a": 1}`).To(golden("json.golden"))
+		Expect(map[string]interface{}{"b": []string{"<x>"}, "a": 1}).To(golden("json.golden"))
+	})
+
+	It("normalizes hand-written golden files", func() {
+		Expect(`{"a":1,"b":2}`).To(golden("compact.golden"))
+		Expect(`{"b":2,"a":1}`).To(golden("compact.golden"))
+		Expect(map[string]int{"b": 2, "a": 1}).To(golden("compact.golden"))
+		Expect(struct {
+			B int `json:"b"`
+			A int `json:"a"`
+		}{B: 2, A: 1}).To(golden("compact.golden"))
+		Expect(`{"a":1,"b":3}`).ToNot(golden("compact.golden"))
+	})
+
+	It("matches response recorder bodies", func() {
+		rr := &httptest.ResponseRecorder{Body: bytes.NewBufferString(`{"a":1,"b":["<x>"]}`)}
+		Expect(rr).To(golden("json.golden"))
+	})
+
+	It("fails with a unified diff", func() {
+		matcher := golden("text.golden")
+		success, err := matcher.Match("line1\nchanged\nline3\n")
+		Expect(success).To(BeFalse())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matcher.FailureMessage(nil)).To(Equal(`Expected actual to match golden file ` + filepath.Join(rootdir(), "text.golden") + ` (hint)
+--- ` + filepath.Join(rootdir(), "text.golden") + `
++++ actual
+@@ -1,3 +1,3 @@
+ line1
+-line2
++changed
+ line3
+`))
+	})
+
+	It("marks a missing newline at the end of the file in the diff", func() {
+		matcher := golden("text.golden")
+		success, err := matcher.Match("line1\nline2\nline3")
+		Expect(success).To(BeFalse())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matcher.FailureMessage(nil)).To(HaveSuffix(`
++++ actual
+@@ -1,3 +1,3 @@
+ line1
+ line2
+-line3
++line3
+\ No newline at end of file
+`))
+	})
+
+	It("fails if the golden file does not exist", func() {
+		matcher := golden("missing.golden")
+		success, err := matcher.Match("x")
+		Expect(success).To(BeFalse())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matcher.FailureMessage(nil)).To(HaveSuffix("missing.golden does not exist (hint)"))
+	})
+
+	It("writes the golden file in update mode", func() {
+		matcher := golden("sub/new.golden")
+		matcher.Update = true
+		Expect(`{"z":1,"y":2}`).To(matcher)
+		Expect(ioutil.ReadFile(filepath.Join(rootdir(), "sub", "new.golden"))).To(BeEquivalentTo("{\n  \"y\": 2,\n  \"z\": 1\n}\n"))
+	})
+
+	It("errors if the actual cannot be encoded", func() {
+		success, err := golden("text.golden").Match(make(chan int))
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError(HavePrefix("MatchGoldenFile cannot encode actual as JSON")))
+	})
+
+	It("only shows context around changes", func() {
+		matcher := golden("long.golden")
+		Expect(ioutil.WriteFile(matcher.Path, []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n"), 0644)).To(Succeed())
+		success, err := matcher.Match("1\nx\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\ny\n14\n")
+		Expect(success).To(BeFalse())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matcher.FailureMessage(nil)).To(HaveSuffix(`
+@@ -1,5 +1,5 @@
+ 1
+-2
++x
+ 3
+ 4
+ 5
+@@ -10,5 +10,5 @@
+ 10
+ 11
+ 12
+-13
++y
+ 14
+`))
+	})
+})
@@ -0,0 +1,74 @@
+package matchers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/rgalanakis/golangal/internal"
+)
+
+type MatchJSONSchemaMatcher struct {
+	// Schema is a JSON string or []byte, or a value that marshals to the schema JSON.
+	Schema interface{}
+
+	instance   interface{}
+	violations []internal.SchemaViolation
+}
+
+// Match errors if the actual cannot be decoded as JSON.
+// Only instances that do not conform to the schema fail.
+func (m *MatchJSONSchemaMatcher) Match(actual interface{}) (success bool, err error) {
+	schema, err := internal.NormalizeJSON(m.Schema)
+	if err != nil {
+		return false, fmt.Errorf("MatchJSONSchema schema is not valid JSON: %v", err)
+	}
+	var body []byte
+	if msg, err := requireHttpMessage(actual); err == nil {
+		if msg.bodyErr != nil {
+			return false, msg.bodyErr
+		}
+		body = msg.Body
+	} else if b, ok := actual.([]byte); ok {
+		body = b
+	} else if b, ok := actual.(json.RawMessage); ok {
+		body = b
+	}
+	if body != nil {
+		if m.instance, err = internal.NormalizeJSON(body); err != nil {
+			return false, errors.New(decodeErrorMessage(err, body))
+		}
+	} else if m.instance, err = jsonSchemaInstance(actual); err != nil {
+		return false, fmt.Errorf("Error decoding\n%s\nas JSON: %v", format.Object(actual, 1), err)
+	}
+	v := &internal.SchemaValidator{Root: schema}
+	m.violations, err = v.Validate(schema, m.instance)
+	if err != nil {
+		return false, err
+	}
+	return len(m.violations) == 0, nil
+}
+
+func (m *MatchJSONSchemaMatcher) FailureMessage(actual interface{}) (message string) {
+	lines := make([]string, len(m.violations))
+	for i, v := range m.violations {
+		lines[i] = format.Indent + v.String()
+	}
+	return fmt.Sprintf("Expected\n%s\nto match JSON schema, but found %d violation(s):\n%s",
+		format.Object(m.instance, 1), len(m.violations), strings.Join(lines, "\n"))
+}
+
+func (m *MatchJSONSchemaMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected\n%s\nnot to match JSON schema", format.Object(m.instance, 1))
+}
+
+// jsonSchemaInstance converts an actual that is not JSON text into decoded JSON values.
+// A string is an instance itself, since HaveJsonBody decodes a JSON string body into one.
+func jsonSchemaInstance(actual interface{}) (interface{}, error) {
+	if s, ok := actual.(string); ok {
+		return s, nil
+	}
+	return internal.NormalizeJSON(actual)
+}
@@ -0,0 +1,131 @@
+package matchers_test
+
+import (
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("MatchJSONSchema matcher", func() {
+	userSchema := `{
+"$defs": {
+	"user": {
+		"type": "object",
+		"required": ["id", "name"],
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"name": {"type": "string", "minLength": 1, "maxLength": 10},
+			"email": {"type": ["string", "null"], "pattern": "^[^@]+@[^@]+$"},
+			"role": {"enum": ["admin", "member"]},
+			"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2}
+		},
+		"additionalProperties": false
+	}
+},
+"type": "object",
+"required": ["users"],
+"properties": {
+	"users": {"type": "array", "items": {"$ref": "#/$defs/user"}, "minItems": 1}
+}
+}`
+
+	It("matches a valid instance", func() {
+		Expect([]byte(`{"users": [{"id": 1, "name": "Rob", "email": null, "role": "admin", "tags": ["a"]}]}`)).
+			To(MatchJSONSchema(userSchema))
+		Expect(map[string]interface{}{"users": []map[string]interface{}{{"id": 2, "name": "Al"}}}).
+			To(MatchJSONSchema(userSchema))
+	})
+
+	It("can be used directly on responses or inside HaveJsonBody", func() {
+		rr := httptest.NewRecorder()
+		rr.WriteString(`{"users": [{"id": 1, "name": "Rob"}]}`)
+		Expect(rr).To(MatchJSONSchema(userSchema))
+		Expect(rr).To(HaveJsonBody(MatchJSONSchema(userSchema)))
+		Expect(rr).To(HaveJsonBody(AtPath("users[0]", MatchJSONSchema(map[string]interface{}{"required": []string{"id"}}))))
+	})
+
+	It("treats strings as instances, not JSON text", func() {
+		Expect("abc").To(MatchJSONSchema(`{"type": "string"}`))
+		Expect(`{"a": 1}`).ToNot(MatchJSONSchema(`{"type": "object"}`))
+		rr := httptest.NewRecorder()
+		rr.WriteString(`"abc"`)
+		Expect(rr).To(HaveJsonBody(MatchJSONSchema(`{"type": "string", "minLength": 3}`)))
+		Expect(rr).To(MatchJSONSchema(`{"type": "string"}`))
+		Expect(rr).ToNot(HaveJsonBody(MatchJSONSchema(`{"type": "object"}`)))
+	})
+
+	It("lists every violation with its JSON pointer", func() {
+		matcher := MatchJSONSchema(userSchema)
+		actual := []byte(`{"users": [
+{"id": 1.5, "name": "", "email": "nope", "role": "owner", "tags": ["a", 1, "c"], "extra": true},
+{"name": "Rob"}
+]}`)
+		Expect(matcher.Match(actual)).To(BeFalse())
+		Expect(matcher.FailureMessage(actual)).To(HaveSuffix(`to match JSON schema, but found 8 violation(s):
+    #/users/0/email: "nope" does not match pattern "^[^@]+@[^@]+$"
+    #/users/0: additional property "extra" is not allowed
+    #/users/0/id: expected type integer, got number
+    #/users/0/name: length 0 is less than minLength 1
+    #/users/0/role: "owner" is not one of ["admin","member"]
+    #/users/0/tags: has 3 items, more than maxItems 2
+    #/users/0/tags/1: expected type string, got number
+    #/users/1: missing required property "id"`))
+	})
+
+	DescribeTable("checks keywords",
+		func(schema, actual, violation string) {
+			matcher := MatchJSONSchema(schema)
+			Expect(matcher.Match([]byte(actual))).To(BeFalse())
+			Expect(matcher.FailureMessage([]byte(actual))).To(HaveSuffix("\n    " + violation))
+		},
+		Entry("type", `{"type": "object"}`, `[]`, "#: expected type object, got array"),
+		Entry("multiple types", `{"type": ["string", "null"]}`, `true`, "#: expected type string or null, got boolean"),
+		Entry("minimum", `{"minimum": 2}`, `1`, "#: 1 is less than minimum 2"),
+		Entry("maximum", `{"maximum": 2}`, `3`, "#: 3 is more than maximum 2"),
+		Entry("exclusiveMinimum", `{"exclusiveMinimum": 2}`, `2`, "#: 2 is not more than exclusiveMinimum 2"),
+		Entry("exclusiveMaximum", `{"exclusiveMaximum": 2}`, `2`, "#: 2 is not less than exclusiveMaximum 2"),
+		Entry("maxLength counts runes", `{"maxLength": 1}`, `"éé"`, "#: length 2 is more than maxLength 1"),
+		Entry("minItems", `{"minItems": 1}`, `[]`, "#: has 0 items, fewer than minItems 1"),
+		Entry("false schema", `{"properties": {"a": false}}`, `{"a": 1}`, "#/a: no value is allowed"),
+		Entry("additionalProperties schema", `{"additionalProperties": {"type": "string"}}`, `{"a/b": 1}`, "#/a~1b: expected type string, got number"),
+		Entry("enum of objects", `{"enum": [{"a": 1}]}`, `{"a": 2}`, `#: {"a":2} is not one of [{"a":1}]`),
+		Entry("$ref to root", `{"properties": {"child": {"$ref": "#"}}, "required": ["x"]}`, `{"x": 1, "child": {}}`, `#/child: missing required property "x"`),
+	)
+
+	It("errors if the body is not JSON, even when negated", func() {
+		rr := httptest.NewRecorder()
+		rr.WriteString(`{"a"`)
+		_, err := MatchJSONSchema(`{}`).Match(rr)
+		Expect(err).To(MatchError(HavePrefix("Error decoding body: unexpected end of JSON input")))
+
+		rr = httptest.NewRecorder()
+		rr.WriteString(`<html>Internal Server Error</html>`)
+		_, err = MatchJSONSchema(`{"required": ["debug"]}`).Match(rr)
+		Expect(err).To(MatchError(HavePrefix("Error decoding body: invalid character '<'")))
+
+		_, err = MatchJSONSchema(`{}`).Match([]byte(`nope`))
+		Expect(err).To(MatchError(HavePrefix("Error decoding body: invalid character 'o'")))
+	})
+
+	DescribeTable("errors for invalid schemas",
+		func(schema, msg string) {
+			_, err := MatchJSONSchema(schema).Match(map[string]interface{}{"a": "b"})
+			Expect(err).To(MatchError(msg))
+		},
+		Entry("not JSON", `{`, "MatchJSONSchema schema is not valid JSON: unexpected end of JSON input"),
+		Entry("missing $ref", `{"$ref": "#/$defs/x"}`, "invalid schema: $ref #/$defs/x not found"),
+		Entry("remote $ref", `{"$ref": "other.json"}`, "invalid schema: only $ref to a JSON pointer within the document is supported, got other.json"),
+		Entry("circular $ref", `{"$defs": {"a": {"$ref": "#/$defs/b"}, "b": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`, "invalid schema: circular $ref #/$defs/a"),
+		Entry("bad pattern", `{"properties": {"a": {"pattern": "("}}}`, "invalid schema: pattern \"(\": error parsing regexp: missing closing ): `(`"),
+		Entry("unknown type", `{"type": "float"}`, `invalid schema: unknown type "float"`),
+	)
+
+	It("can be negated", func() {
+		Expect([]byte(`{}`)).ToNot(MatchJSONSchema(`{"required": ["a"]}`))
+		matcher := MatchJSONSchema(`{}`)
+		Expect(matcher.Match([]byte(`{"a": 1}`))).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage([]byte(`{"a": 1}`))).To(HaveSuffix("not to match JSON schema"))
+	})
+})
@@ -0,0 +1,77 @@
+package matchers
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/onsi/gomega/types"
+	"github.com/rgalanakis/golangal/internal"
+)
+
+type RedirectToMatcher struct {
+	// Location is a string or matcher for the Location header.
+	Location interface{}
+	// Code is the exact redirect status code expected, or 0 for any 3xx code.
+	Code int
+
+	msg      *httpMessage
+	location string
+	inner    types.GomegaMatcher
+}
+
+func (m *RedirectToMatcher) Match(actual interface{}) (success bool, err error) {
+	msg, err := requireResponse(actual)
+	if err != nil {
+		return false, err
+	}
+	m.msg = msg
+	m.inner = nil
+	if !Status3xx.Contains(msg.Code) || (m.Code != 0 && msg.Code != m.Code) {
+		return false, nil
+	}
+	m.location = msg.Header.Get("Location")
+	if m.location == "" {
+		return false, nil
+	}
+	expected := m.Location
+	if msg.URL != nil {
+		if m.location, err = resolveURL(msg.URL, m.location); err != nil {
+			return false, fmt.Errorf("invalid Location header: %v", err)
+		}
+		if s, ok := expected.(string); ok {
+			if expected, err = resolveURL(msg.URL, s); err != nil {
+				return false, fmt.Errorf("invalid expected location: %v", err)
+			}
+		}
+	}
+	m.inner = internal.CoerceToMatcher(expected)
+	return m.inner.Match(m.location)
+}
+
+func (m *RedirectToMatcher) FailureMessage(actual interface{}) (message string) {
+	var reason string
+	switch {
+	case !Status3xx.Contains(m.msg.Code) && m.Code == 0:
+		reason = fmt.Sprintf("Expected a redirect, but status was %s", describeStatus(m.msg.Code))
+	case m.Code != 0 && m.msg.Code != m.Code:
+		reason = fmt.Sprintf("Expected redirect status %s, but status was %s", describeStatus(m.Code), describeStatus(m.msg.Code))
+	case m.inner == nil:
+		reason = fmt.Sprintf("Expected a Location header for redirect status %s, but there was none", describeStatus(m.msg.Code))
+	default:
+		reason = "Redirect location did not match. " + m.inner.FailureMessage(m.location)
+	}
+	return reason + "\n" + m.msg.Describe()
+}
+
+func (m *RedirectToMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected not to redirect to a matching location, but status was %s and location was %s",
+		describeStatus(m.msg.Code), m.location)
+}
+
+func resolveURL(base *url.URL, ref string) (string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(u).String(), nil
+}
@@ -0,0 +1,88 @@
+package matchers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("RedirectToMatcher", func() {
+	redirect := func(code int, location string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		if location != "" {
+			rr.Header().Set("Location", location)
+		}
+		rr.WriteHeader(code)
+		return rr
+	}
+
+	It("matches a 3xx code and location", func() {
+		Expect(redirect(302, "/login")).To(RedirectTo("/login"))
+		Expect(redirect(308, "https://x.com/a")).To(RedirectTo(HavePrefix("https://x.com/")))
+		Expect(redirect(302, "/login")).ToNot(RedirectTo("/logout"))
+		Expect(redirect(200, "/login")).ToNot(RedirectTo("/login"))
+	})
+
+	It("can require an exact code", func() {
+		Expect(redirect(301, "/new")).To(RedirectToWithCode(http.StatusMovedPermanently, "/new"))
+		Expect(redirect(302, "/new")).ToNot(RedirectToWithCode(http.StatusMovedPermanently, "/new"))
+	})
+
+	It("resolves relative locations against the request URL", func() {
+		resp := redirect(303, "../done?x=1").Result()
+		resp.Request = httptest.NewRequest("POST", "https://example.com/orders/5/submit", nil)
+		Expect(resp).To(RedirectTo("https://example.com/orders/done?x=1"))
+		Expect(resp).To(RedirectTo("/orders/done?x=1"))
+		Expect(resp).To(RedirectTo(HavePrefix("https://example.com/orders/done")))
+	})
+
+	It("fails if the status is not a redirect", func() {
+		rr := redirect(200, "")
+		rr.WriteString("hi")
+		matcher := RedirectTo("/login")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(Equal("Expected a redirect, but status was 200 (OK)\nBody:\nhi"))
+	})
+
+	It("fails if the code is not the exact code", func() {
+		rr := redirect(302, "/new")
+		matcher := RedirectToWithCode(307, "/new")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(HavePrefix(
+			"Expected redirect status 307 (Temporary Redirect), but status was 302 (Found)\nHeaders:\n    Location: /new\n"))
+	})
+
+	It("fails if there is no Location header", func() {
+		rr := redirect(302, "")
+		matcher := RedirectTo("/new")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(HavePrefix(
+			"Expected a Location header for redirect status 302 (Found), but there was none\n"))
+	})
+
+	It("fails if the location does not match", func() {
+		rr := redirect(302, "/old")
+		matcher := RedirectTo("/new")
+		Expect(matcher.Match(rr)).To(BeFalse())
+		Expect(matcher.FailureMessage(rr)).To(HavePrefix(`Redirect location did not match. Expected
+    <string>: /old
+to equal
+    <string>: /new`))
+	})
+
+	It("errors for a request", func() {
+		_, err := RedirectTo("/").Match(httptest.NewRequest("GET", "/", nil))
+		Expect(err).To(MatchError("actual must be a *httptest.ResponseRecorder or *http.Response"))
+	})
+
+	It("fails negated with the status and location", func() {
+		rr := redirect(302, "/new")
+		matcher := RedirectTo("/new")
+		Expect(matcher.Match(rr)).To(BeTrue())
+		Expect(matcher.NegatedFailureMessage(rr)).To(Equal(
+			"Expected not to redirect to a matching location, but status was 302 (Found) and location was /new"))
+	})
+})
@@ -0,0 +1,108 @@
+package golangal
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+// FreePortBase is the first port FreePort allocates from.
+var FreePortBase = 20000
+
+// FreePortsPerProcess is the number of ports each parallel Ginkgo process can allocate from.
+// Process N allocates from FreePortBase+(N-1)*FreePortsPerProcess.
+var FreePortsPerProcess = 500
+
+// FreePort returns a TCP port that is free on 127.0.0.1, for code that needs a port number
+// rather than a listener (like a server that takes an address in its config).
+// The port is released when the current node's scope ends (see SetEnv).
+//
+// To avoid collisions when running in parallel (ginkgo -p), each process allocates
+// from its own range of ports, based on GinkgoParallelProcess.
+// Within a process, a port is not handed out again until it is released,
+// and recently released ports are reused last.
+//
+// If you can, prefer EachListener, since there is always a chance something outside
+// the test suite takes the port between FreePort returning and the port being bound.
+func FreePort() int {
+	port, err := freePorts.allocate(ginkgo.GinkgoParallelProcess())
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	ginkgo.DeferCleanup(freePorts.release, port)
+	return port
+}
+
+var freePorts = &portAllocator{allocated: make(map[int]bool)}
+
+type portAllocator struct {
+	mu        sync.Mutex
+	allocated map[int]bool
+	next      int
+}
+
+func (a *portAllocator) allocate(process int) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	start := FreePortBase + (process-1)*FreePortsPerProcess
+	for i := 0; i < FreePortsPerProcess; i++ {
+		port := start + (a.next+i)%FreePortsPerProcess
+		if a.allocated[port] || !portAvailable(port) {
+			continue
+		}
+		a.allocated[port] = true
+		a.next = (a.next + i + 1) % FreePortsPerProcess
+		return port, nil
+	}
+	return 0, fmt.Errorf("no free ports between %d and %d", start, start+FreePortsPerProcess-1)
+}
+
+func (a *portAllocator) release(port int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.allocated, port)
+}
+
+func portAvailable(port int) bool {
+	l, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return false
+	}
+	_ = l.Close()
+	return true
+}
+
+// EachListener returns a function that returns a TCP listener bound to a random port on 127.0.0.1.
+// A new listener is created before each test, and closed after the test
+// (it is fine for the test to close it first).
+//
+// Example:
+//
+//	listener := golangal.EachListener()
+//	It("serves requests", func() {
+//	  go http.Serve(listener(), handler)
+//	  resp, err := http.Get("http://" + listener().Addr().String())
+//	  ...
+//	})
+func EachListener() func() net.Listener {
+	var listener net.Listener
+	ginkgo.BeforeEach(func() {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		listener = l
+		ginkgo.DeferCleanup(closeListener, l)
+	})
+	return func() net.Listener {
+		return listener
+	}
+}
+
+func closeListener(l net.Listener) error {
+	if err := l.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package golangal_test
+
+import (
+	"net"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("FreePort and EachListener", func() {
+	Describe("FreePort", func() {
+		It("returns distinct, bindable ports in this process's range", func() {
+			start := golangal.FreePortBase + (GinkgoParallelProcess()-1)*golangal.FreePortsPerProcess
+			seen := map[int]bool{}
+			for i := 0; i < 5; i++ {
+				port := golangal.FreePort()
+				Expect(port).To(BeNumerically(">=", start))
+				Expect(port).To(BeNumerically("<", start+golangal.FreePortsPerProcess))
+				Expect(seen).ToNot(HaveKey(port))
+				seen[port] = true
+			}
+			for port := range seen {
+				l, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(port))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(l.Close()).To(Succeed())
+			}
+		})
+
+		It("skips ports that are in use", func() {
+			port := golangal.FreePort()
+			next := port + 1
+			l, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(next))
+			Expect(err).ToNot(HaveOccurred())
+			defer l.Close()
+			Expect(golangal.FreePort()).ToNot(Equal(next))
+		})
+	})
+
+	Describe("EachListener", func() {
+		listener := golangal.EachListener()
+
+		var previous net.Listener
+		It("returns a bound listener", func() {
+			Expect(listener().Addr().String()).To(HavePrefix("127.0.0.1:"))
+			conn, err := net.Dial("tcp", listener().Addr().String())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conn.Close()).To(Succeed())
+			previous = listener()
+		})
+
+		It("closes the listener after the test", func() {
+			_, err := previous.Accept()
+			Expect(err).To(MatchError(net.ErrClosed))
+			Expect(listener()).ToNot(BeIdenticalTo(previous))
+		})
+
+		It("allows the test to close the listener", func() {
+			Expect(listener().Close()).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,79 @@
+package golangal_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("Request", func() {
+	// echo responds with a JSON description of the request.
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		user, pass, _ := r.BasicAuth()
+		cookies := map[string]string{}
+		for _, c := range r.Cookies() {
+			cookies[c.Name] = c.Value
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(201)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"method":      r.Method,
+			"path":        r.URL.Path,
+			"query":       r.URL.Query(),
+			"contentType": r.Header.Get("Content-Type"),
+			"custom":      r.Header.Values("X-Custom"),
+			"cookies":     cookies,
+			"basicAuth":   user + ":" + pass,
+			"body":        string(body),
+		})
+	})
+
+	It("serves a request to a handler and returns the recorder", func() {
+		rr := golangal.Request("POST", "/users").JSON(map[string]int{"a": 1}).ServeTo(echo)
+		Expect(rr).To(golangal.HaveResponseCode(201))
+		Expect(rr).To(golangal.HaveJsonBody(golangal.AtPath("method", "POST")))
+		Expect(rr).To(golangal.HaveJsonBody(golangal.AtPath("path", "/users")))
+		Expect(rr).To(golangal.HaveJsonBody(golangal.AtPath("contentType", "application/json")))
+		Expect(rr).To(golangal.HaveJsonBody(golangal.AtPath("body", MatchJSON(`{"a": 1}`))))
+	})
+
+	It("uses string and []byte JSON bodies as is", func() {
+		Expect(golangal.Request("PUT", "/").JSON(`{"b": 2}`).ServeTo(echo)).To(golangal.HaveJsonBody(golangal.AtPath("body", `{"b": 2}`)))
+		Expect(golangal.Request("PUT", "/").JSON([]byte(`[]`)).ServeTo(echo)).To(golangal.HaveJsonBody(golangal.AtPath("body", `[]`)))
+	})
+
+	It("sets form bodies", func() {
+		req := golangal.Request("POST", "/login").Form(url.Values{"name": {"Rob"}}).Build()
+		Expect(req).To(golangal.HaveFormBody(HaveKeyWithValue("name", []string{"Rob"})))
+	})
+
+	It("adds headers, cookies, query parameters, and basic auth", func() {
+		rr := golangal.Request("GET", "/search?q=a").
+			Header("X-Custom", "1").Header("X-Custom", "2").
+			Cookie("session", "abc").Cookie("theme", "dark").
+			Query("q", "b").Query("page", "2").
+			BasicAuth("rob", "secret").
+			ServeTo(echo)
+		Expect(rr).To(golangal.HaveJsonBody(SatisfyAll(
+			golangal.AtPath("query.q", ConsistOf("a", "b")),
+			golangal.AtPath("query.page", ConsistOf("2")),
+			golangal.AtPath("custom", ConsistOf("1", "2")),
+			golangal.AtPath("cookies", Equal(map[string]interface{}{"session": "abc", "theme": "dark"})),
+			golangal.AtPath("basicAuth", "rob:secret"),
+		)))
+	})
+
+	It("builds a request usable with the request matchers", func() {
+		req := golangal.Request("GET", "/").Cookie("session", "abc").Header("Accept", "text/html").Build()
+		Expect(req).To(golangal.HaveCookie("session", golangal.MatchPtrField("Value", "abc")))
+		Expect(req).To(golangal.HaveHeader("Accept", "text/html"))
+		Expect(req.RequestURI).To(Equal("/"))
+		Expect(golangal.Request("GET", "/a?x=1").Query("y", "2").Build().RequestURI).To(Equal("/a?x=1&y=2"))
+	})
+})
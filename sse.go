@@ -0,0 +1,48 @@
+package golangal
+
+import (
+	"net/http"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/rgalanakis/golangal/matchers"
+)
+
+// SSEEvent is an event parsed from a text/event-stream (Server-Sent Events) body.
+type SSEEvent = matchers.SSEEvent
+
+// StreamSSE starts reading the body of resp in the background,
+// so its events can be matched with HaveSSEEvents while the connection is still open.
+// The body is closed when the current node's scope ends (see SetEnv).
+//
+//	resp, err := http.Get(server.URL + "/events")
+//	Expect(err).ToNot(HaveOccurred())
+//	stream := golangal.StreamSSE(resp)
+//	Eventually(stream).Should(HaveSSEEvents("hello", "world"))
+func StreamSSE(resp *http.Response) *matchers.SSEStream {
+	ginkgo.DeferCleanup(resp.Body.Close)
+	return matchers.NewSSEStream(resp.Header, resp.Body)
+}
+
+// HaveSSEEvents succeeds if a text/event-stream body has exactly the given events, in order.
+// The actual can be an *httptest.ResponseRecorder, a finished *http.Response,
+// the result of StreamSSE (for use with Eventually), or a []SSEEvent.
+// Each event can be an SSEEvent, a string to match against the event's Data,
+// or a matcher of SSEEvent:
+//
+//	Expect(rr).To(HaveSSEEvents(
+//	  "connected",
+//	  SSEEvent{ID: "1", Event: "update", Data: `{"a":1}`},
+//	  MatchField("Event", "done"),
+//	))
+//
+// The Content-Type must be text/event-stream.
+func HaveSSEEvents(events ...interface{}) gomega.OmegaMatcher {
+	return &matchers.HaveSSEEventsMatcher{Events: events}
+}
+
+// HaveSSEEventsInAnyOrder is like HaveSSEEvents, but the events can be in any order
+// (like ConsistOf).
+func HaveSSEEventsInAnyOrder(events ...interface{}) gomega.OmegaMatcher {
+	return &matchers.HaveSSEEventsMatcher{Events: events, AnyOrder: true}
+}
@@ -0,0 +1,41 @@
+package golangal_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("StreamSSE", func() {
+	It("matches events from an open connection with Eventually", func() {
+		send := make(chan string)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.(http.Flusher).Flush()
+			for {
+				select {
+				case data := <-send:
+					fmt.Fprintf(w, "data: %s\n\n", data)
+					w.(http.Flusher).Flush()
+				case <-r.Context().Done():
+					return
+				}
+			}
+		}))
+		DeferCleanup(server.Close)
+
+		resp, err := http.Get(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		stream := golangal.StreamSSE(resp)
+		Consistently(stream, "50ms").Should(golangal.HaveSSEEvents())
+		send <- "one"
+		Eventually(stream).Should(golangal.HaveSSEEvents("one"))
+		send <- "two"
+		Eventually(stream).Should(golangal.HaveSSEEvents("one", "two"))
+		Expect(stream.Err()).ToNot(HaveOccurred())
+	})
+})
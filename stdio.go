@@ -0,0 +1,138 @@
+package golangal
+
+import (
+	"io"
+	"os"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+// Stdio captures os.Stdout and os.Stderr, and the exit code of a CLI entrypoint,
+// for the duration of a test. Create it with EachStdio.
+type Stdio struct {
+	// Stdout contains everything written to os.Stdout during the test.
+	// Use it with gbytes.Say.
+	Stdout *gbytes.Buffer
+	// Stderr contains everything written to os.Stderr during the test.
+	Stderr *gbytes.Buffer
+
+	exitCode int
+	stdout   *pipeCapture
+	stderr   *pipeCapture
+}
+
+// EachStdio returns a Stdio that captures os.Stdout and os.Stderr through pipes
+// before each test, and restores them after each test.
+//
+// Code that calls os.Exit cannot be tested, so CLI entrypoints should call
+// an overridable function instead. Set it to Stdio.Exit,
+// and invoke the entrypoint using Stdio.Run.
+// Use HaveExitedWith to check the exit code.
+//
+// Example:
+//
+//	// In main.go
+//	var exit = os.Exit
+//
+//	// In main_test.go
+//	stdio := golangal.EachStdio()
+//	BeforeEach(func() {
+//	  exit = stdio.Exit
+//	})
+//	It("errors for missing args", func() {
+//	  stdio.Run(main)
+//	  Expect(stdio).To(HaveExitedWith(2))
+//	  Expect(stdio.Stderr).To(gbytes.Say("usage:"))
+//	})
+func EachStdio() *Stdio {
+	s := &Stdio{}
+	ginkgo.BeforeEach(func() {
+		s.Stdout = gbytes.NewBuffer()
+		s.Stderr = gbytes.NewBuffer()
+		s.exitCode = -1
+		s.start()
+		ginkgo.DeferCleanup(s.stop)
+	})
+	return s
+}
+
+type exitPanic struct {
+	code int
+}
+
+// Exit records the exit code and stops execution of the function passed to Run,
+// like os.Exit stops the process.
+// It must be called from the goroutine running Run.
+func (s *Stdio) Exit(code int) {
+	panic(exitPanic{code: code})
+}
+
+// ExitCode returns the code passed to Exit during the last Run,
+// or -1 if Exit was not called.
+func (s *Stdio) ExitCode() int {
+	return s.exitCode
+}
+
+// Run calls f, stopping it if it calls Exit.
+// Any other panic is re-raised.
+// When Run returns, everything f has written to os.Stdout and os.Stderr
+// is available in Stdout and Stderr.
+func (s *Stdio) Run(f func()) {
+	s.exitCode = -1
+	defer s.flush()
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(exitPanic)
+			if !ok {
+				panic(r)
+			}
+			s.exitCode = e.code
+		}
+	}()
+	f()
+}
+
+func (s *Stdio) start() {
+	s.stdout = startPipeCapture(&os.Stdout, s.Stdout)
+	s.stderr = startPipeCapture(&os.Stderr, s.Stderr)
+}
+
+func (s *Stdio) stop() {
+	s.stdout.stop()
+	s.stderr.stop()
+}
+
+// flush makes sure everything written so far is in the buffers,
+// by closing the current pipes and starting new ones.
+func (s *Stdio) flush() {
+	s.stop()
+	s.start()
+}
+
+type pipeCapture struct {
+	target   **os.File
+	original *os.File
+	w        *os.File
+	done     chan struct{}
+}
+
+func startPipeCapture(target **os.File, buf *gbytes.Buffer) *pipeCapture {
+	r, w, err := os.Pipe()
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	pc := &pipeCapture{target: target, original: *target, w: w, done: make(chan struct{})}
+	*target = w
+	go func() {
+		defer close(pc.done)
+		_, _ = io.Copy(buf, r)
+		_ = r.Close()
+	}()
+	return pc
+}
+
+func (pc *pipeCapture) stop() {
+	*pc.target = pc.original
+	_ = pc.w.Close()
+	<-pc.done
+}
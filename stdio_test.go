@@ -0,0 +1,78 @@
+package golangal_test
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("EachStdio", func() {
+	var originalStdout, originalStderr *os.File
+	BeforeEach(func() {
+		originalStdout, originalStderr = os.Stdout, os.Stderr
+		DeferCleanup(func() {
+			Expect(os.Stdout).To(BeIdenticalTo(originalStdout))
+			Expect(os.Stderr).To(BeIdenticalTo(originalStderr))
+		})
+	})
+
+	stdio := golangal.EachStdio()
+	exit := os.Exit
+	BeforeEach(func() {
+		exit = stdio.Exit
+	})
+
+	cli := func(args ...string) func() {
+		return func() {
+			if len(args) == 0 {
+				fmt.Fprintln(os.Stderr, "usage: cli <name>")
+				exit(2)
+			}
+			fmt.Fprintf(os.Stdout, "hello, %s\n", args[0])
+		}
+	}
+
+	It("captures stdout and stderr", func() {
+		stdio.Run(cli("world"))
+		Expect(stdio.Stdout).To(gbytes.Say("hello, world"))
+		Expect(stdio.Stderr.Contents()).To(BeEmpty())
+		Expect(stdio).ToNot(golangal.HaveExitedWith(BeNumerically(">=", 0)))
+		Expect(stdio.ExitCode()).To(Equal(-1))
+	})
+
+	It("captures the exit code", func() {
+		stdio.Run(cli())
+		Expect(stdio).To(golangal.HaveExitedWith(2))
+		Expect(stdio.Stderr).To(gbytes.Say("usage: cli"))
+	})
+
+	It("can capture multiple runs", func() {
+		stdio.Run(cli("a"))
+		Expect(stdio.Stdout).To(gbytes.Say("hello, a"))
+		stdio.Run(cli("b"))
+		Expect(stdio.Stdout).To(gbytes.Say("hello, b"))
+	})
+
+	It("resets the exit code for each run", func() {
+		stdio.Run(cli())
+		Expect(stdio).To(golangal.HaveExitedWith(2))
+		stdio.Run(cli("a"))
+		Expect(stdio.ExitCode()).To(Equal(-1))
+		Expect(stdio).ToNot(golangal.HaveExitedWith(2))
+	})
+
+	It("captures output outside of Run", func() {
+		fmt.Fprint(os.Stdout, "direct")
+		Eventually(stdio.Stdout).Should(gbytes.Say("direct"))
+	})
+
+	It("re-raises other panics", func() {
+		Expect(func() {
+			stdio.Run(func() { panic("oops") })
+		}).To(PanicWith("oops"))
+	})
+})
@@ -0,0 +1,116 @@
+package golangal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+// EachWorkDir is like EachTempDir, but also changes the working directory into
+// the temporary directory before each test, and restores the original working directory
+// after the test. See ChangeWorkDir for details.
+//
+// Example:
+//
+//	workdir := golangal.EachWorkDir()
+//	It("finds the config in the working directory", func() {
+//	  golangal.WriteFileTree(workdir(), golangal.FileTree{"app.yml": "debug: true"})
+//	  Expect(FindConfig()).To(Equal("app.yml"))
+//	})
+func EachWorkDir() func() string {
+	tempdir := EachTempDir()
+	ginkgo.BeforeEach(func() {
+		ChangeWorkDir(tempdir())
+	})
+	return tempdir
+}
+
+// ChangeWorkDir changes the working directory to dir, and restores the original
+// working directory when the current node's scope ends (see SetEnv).
+// To use a directory from another fixture, call it from a BeforeEach:
+//
+//	rootdir := golangal.EachFileTree(golangal.FileTree{"a/b/.keep": ""})
+//	BeforeEach(func() {
+//	  golangal.ChangeWorkDir(filepath.Join(rootdir(), "a", "b"))
+//	})
+//
+// The working directory is process-wide, so ChangeWorkDir fails the test if the working directory
+// is changed or restored out of order, which means tests are running in parallel
+// within one process, or something else is changing the working directory.
+// Parallel Ginkgo processes (ginkgo -p) are safe.
+func ChangeWorkDir(dir string) {
+	original, err := os.Getwd()
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(original, dir)
+	}
+	change := &workDirChange{dir: dir, original: original}
+	gomega.Expect(pushWorkDir(change)).To(gomega.Succeed())
+	ginkgo.DeferCleanup(restoreWorkDir, change)
+}
+
+type workDirChange struct {
+	dir      string
+	original string
+}
+
+var workDirState struct {
+	sync.Mutex
+	stack []*workDirChange
+}
+
+func pushWorkDir(change *workDirChange) error {
+	workDirState.Lock()
+	defer workDirState.Unlock()
+	if n := len(workDirState.stack); n > 0 && !sameDir(change.original, workDirState.stack[n-1].dir) {
+		return fmt.Errorf("ChangeWorkDir expected the working directory to be %s, but it is %s. "+
+			"Tests that change the working directory cannot run in parallel within one process",
+			workDirState.stack[n-1].dir, change.original)
+	}
+	if err := os.Chdir(change.dir); err != nil {
+		return err
+	}
+	workDirState.stack = append(workDirState.stack, change)
+	return nil
+}
+
+func restoreWorkDir(change *workDirChange) error {
+	workDirState.Lock()
+	n := len(workDirState.stack)
+	outOfOrder := n == 0 || workDirState.stack[n-1] != change
+	for i := n - 1; i >= 0; i-- {
+		if workDirState.stack[i] == change {
+			workDirState.stack = append(workDirState.stack[:i], workDirState.stack[i+1:]...)
+			break
+		}
+	}
+	workDirState.Unlock()
+
+	current, getwdErr := os.Getwd()
+	// Restore regardless, so one bad test does not break every test after it.
+	if err := os.Chdir(change.original); err != nil {
+		return err
+	}
+	if getwdErr != nil {
+		return getwdErr
+	}
+	if outOfOrder || !sameDir(current, change.dir) {
+		return fmt.Errorf("working directory was %s when restoring it from %s to %s. "+
+			"Tests that change the working directory cannot run in parallel within one process",
+			current, change.dir, change.original)
+	}
+	return nil
+}
+
+func sameDir(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ra, errA := filepath.EvalSymlinks(a)
+	rb, errB := filepath.EvalSymlinks(b)
+	return errA == nil && errB == nil && ra == rb
+}
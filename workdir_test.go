@@ -0,0 +1,69 @@
+package golangal_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rgalanakis/golangal"
+)
+
+var _ = Describe("EachWorkDir and ChangeWorkDir", func() {
+	var originalWd string
+	BeforeEach(func() {
+		var err error
+		originalWd, err = os.Getwd()
+		Expect(err).ToNot(HaveOccurred())
+		// Registered first, so it runs after every other cleanup.
+		DeferCleanup(func() {
+			Expect(os.Getwd()).To(Equal(originalWd))
+		})
+	})
+
+	Describe("EachWorkDir", func() {
+		workdir := golangal.EachWorkDir()
+
+		It("changes into a temporary directory", func() {
+			Expect(workdir()).To(HavePrefix(os.TempDir()))
+			wd, err := os.Getwd()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filepath.EvalSymlinks(wd)).To(Equal(evalSymlinks(workdir())))
+		})
+	})
+
+	Describe("ChangeWorkDir", func() {
+		rootdir := golangal.EachFileTree(golangal.FileTree{"a/b/c.txt": "c"})
+
+		It("can be nested", func() {
+			golangal.ChangeWorkDir(filepath.Join(rootdir(), "a"))
+			Expect("b").To(BeADirectory())
+			golangal.ChangeWorkDir("b")
+			Expect("c.txt").To(BeARegularFile())
+		})
+
+		It("fails if the working directory is changed by something else", func() {
+			golangal.ChangeWorkDir(rootdir())
+			Expect(os.Chdir("a")).To(Succeed())
+			failure := InterceptGomegaFailure(func() {
+				golangal.ChangeWorkDir("b")
+			})
+			Expect(failure).To(MatchError(ContainSubstring(
+				"Tests that change the working directory cannot run in parallel within one process")))
+			Expect(os.Chdir(rootdir())).To(Succeed())
+		})
+
+		It("fails if the directory does not exist", func() {
+			failure := InterceptGomegaFailure(func() {
+				golangal.ChangeWorkDir(filepath.Join(rootdir(), "missing"))
+			})
+			Expect(failure).To(MatchError(ContainSubstring("no such file or directory")))
+		})
+	})
+})
+
+func evalSymlinks(p string) string {
+	r, err := filepath.EvalSymlinks(p)
+	Expect(err).ToNot(HaveOccurred())
+	return r
+}